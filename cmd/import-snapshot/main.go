@@ -0,0 +1,62 @@
+// Command import-snapshot converts a CKAN export of the vehicle registry
+// resource into the local snapshot served when VEHICLE_SNAPSHOT_PATH is set.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"car-license-number-fetcher/services"
+)
+
+func main() {
+	input := flag.String("input", "", "path to the CKAN CSV or JSON export of the vehicle registry resource")
+	output := flag.String("output", "vehicles.snapshot.db", "path to write the snapshot store to")
+	format := flag.String("format", "", "export format, csv or json (defaults to the input file extension)")
+	snapshotDate := flag.String("date", time.Now().Format(time.DateOnly), "date the export was taken, YYYY-MM-DD")
+	resourceID := flag.String("resource", "", "CKAN resource id the export was taken from")
+	flag.Parse()
+
+	if *input == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := time.Parse(time.DateOnly, *snapshotDate); err != nil {
+		log.Fatalf("Invalid snapshot date %q: %s", *snapshotDate, err)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*input), ".")
+	}
+
+	file, err := os.Open(*input)
+	if err != nil {
+		log.Fatalf("Opening export encountered an error: %s", err)
+	}
+	defer file.Close()
+
+	snapshot, err := services.CreateVehicleSnapshot(*output)
+	if err != nil {
+		log.Fatalf("Creating snapshot encountered an error: %s", err)
+	}
+
+	if err := services.ReadVehicleRecords(file, *format, snapshot.Add); err != nil {
+		snapshot.Abort()
+		log.Fatalf("Importing export encountered an error: %s", err)
+	}
+
+	count, duplicates := snapshot.Count()
+	if err := snapshot.Close(*snapshotDate, *resourceID); err != nil {
+		log.Fatalf("Writing snapshot encountered an error: %s", err)
+	}
+
+	if duplicates > 0 {
+		log.Printf("%d rows repeated an earlier license plate; the last row for each plate was kept", duplicates)
+	}
+	log.Printf("Imported %d vehicle records into %s", count, *output)
+}
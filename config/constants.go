@@ -1,14 +1,51 @@
 package config
 
 const (
-	VehicleDataAPIEndpoint = "https://data.gov.il/api/3/action/datastore_search?resource_id=053cea08-09bc-40ec-8f7a-156f0677aff3&limit=1&q="
+	CKANDatastoreSearchEndpoint = "https://data.gov.il/api/3/action/datastore_search"
+	VehicleResourceID           = "053cea08-09bc-40ec-8f7a-156f0677aff3"
+	MotorcycleResourceID        = "bf9df4e2-d90d-4c0a-a400-19e15af8e95f"
+	HeavyVehicleResourceID      = "cd3acc5c-03c3-4c89-9c54-d40f93c0d790"
+	PersonalImportResourceID    = "03adc637-b6fe-402b-9937-7c3d3afc9140"
+	InactiveVehicleResourceID   = "f6efe89a-fb3d-43a4-bb61-9bf12a9b9099"
+	ScrappedVehicleResourceID   = "851ecab1-0622-4dbe-a6c7-f950cf82abf9"
+	RecallResourceID            = "36bf1404-0be4-49d2-82dc-2f1ead4a8b93"
+	ModelSpecsResourceID        = "142afde2-6228-49f9-8a29-9b6c3a0cbe40"
+	DisabledPermitResourceID    = "c8b9f9c8-4612-4068-934f-d4acd2e3c06e"
+	OwnershipHistoryResourceID  = "bb2355dc-9ec7-4f06-9c3f-3344672171da"
+	CKANMaxRecords              = 1000
+	CKANRequestTimeoutSeconds   = 20
+	EnrichmentTimeoutSeconds    = 5
+	BatchMaxLicensePlates       = 500
+	BatchLookupConcurrency      = 8
+	VehicleSearchPageSize       = 100
+	VehicleSearchDefaultLimit   = 50
+	VehicleSearchMaxLimit       = 500
+	VehicleSearchMaxYearSpan    = 50
+	RegistryTimeZone            = "Asia/Jerusalem"
 	WheelSizeAPIEndpoint   = "https://api.wheel-size.com/v2/search/by_model/"
+	WheelSizeMakesEndpoint = "https://api.wheel-size.com/v2/makes/"
+	WheelSizeModelsEndpoint = "https://api.wheel-size.com/v2/models/"
 	WheelSizeDefaultRegion = "eudm"
+	WheelSizeCatalogTTLHours = 24
+	WheelSizeRequestTimeoutSeconds = 10
+	WheelSizeMinMatchConfidence = 0.6
+	TirePressureProviderWheelSize  = "wheel-size.com"
+	TirePressureProviderLocalTable = "local-table"
+	DefaultTirePressureProviders   = TirePressureProviderWheelSize + "," + TirePressureProviderLocalTable
 	LicensePlateKey       = "licensePlate"
+	PressureUnitQueryKey  = "unit"
+	RegionQueryKey        = "region"
+	DebugQueryKey         = "debug"
+	IncludeQueryKey       = "include"
+	IncludeSpecs          = "specs"
 	VehicleNameKey        = "vehicleName"
 	DefaultPort           = "8080"
 	OpenAIAPIKeyEnvVar    = "OPENAPI_KEY"
 	WheelSizeAPIKeyEnvVar = "WHEEL_SIZE_KEY"
+	VehicleDataAPIEndpointEnvVar = "VEHICLE_DATA_API_ENDPOINT"
+	VehicleSnapshotPathEnvVar    = "VEHICLE_SNAPSHOT_PATH"
+	TirePressureProvidersEnvVar  = "TIRE_PRESSURE_PROVIDERS"
+	TirePressureTablePathEnvVar  = "TIRE_PRESSURE_TABLE_PATH"
 	MobileUserAgent       = "Ktor client"
 	ErrorKey              = "error"
 )
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/gin-gonic/gin v1.10.0
 	github.com/openai/openai-go v0.1.0-alpha.49
+	go.etcd.io/bbolt v1.4.3
 )
 
 require (
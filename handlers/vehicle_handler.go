@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
 
 	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
 	serrors "car-license-number-fetcher/serrors"
 	"car-license-number-fetcher/services"
 	"car-license-number-fetcher/utils"
@@ -12,14 +16,31 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func GetVehiclePlateNumber(c *gin.Context) {
+// VehicleHandler serves the vehicle related endpoints
+type VehicleHandler struct {
+	vehicleService      *services.VehicleService
+	tirePressureService *services.TirePressureService
+	wheelFitmentService *services.WheelFitmentService
+}
+
+func NewVehicleHandler(vehicleService *services.VehicleService, tirePressureService *services.TirePressureService, wheelFitmentService *services.WheelFitmentService) *VehicleHandler {
+	return &VehicleHandler{
+		vehicleService:      vehicleService,
+		tirePressureService: tirePressureService,
+		wheelFitmentService: wheelFitmentService,
+	}
+}
+
+// licensePlateFromRequest validates a mobile request and returns its license
+// plate parameter, responding with an error and returning false otherwise
+func licensePlateFromRequest(c *gin.Context) (string, bool) {
 	if !utils.IsRequestFromMobile(c.Request.UserAgent()) {
 		utils.RespondWithError(
 			c,
 			http.StatusBadRequest,
 			fmt.Errorf("%w: request is not from a mobile device", serrors.ErrInvalidVehicleDetails),
 		)
-		return
+		return "", false
 	}
 
 	licensePlate := c.Param(config.LicensePlateKey)
@@ -29,19 +50,40 @@ func GetVehiclePlateNumber(c *gin.Context) {
 			http.StatusBadRequest,
 			fmt.Errorf("%w: license plate missing from request", serrors.ErrInvalidVehicleDetails),
 		)
+		return "", false
+	}
+
+	return licensePlate, true
+}
+
+func (h *VehicleHandler) GetVehiclePlateNumber(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
 		return
 	}
 
-	vehicleDetails, err := services.FetchVehicleDetailsByLicensePlate(licensePlate)
+	vehicleDetails, err := h.vehicleService.FetchVehicleDetailsByLicensePlate(c.Request.Context(), licensePlate)
 	if err != nil {
 		utils.HandleVehicleDetailsError(c, err, licensePlate)
 		return
 	}
 
+	if err := h.vehicleService.IncludeDisabledPermit(c.Request.Context(), &vehicleDetails); err != nil {
+		log.Printf("GetVehiclePlateNumber: disabled permit lookup failed for %s: %v", licensePlate, err)
+	}
+
+	if utils.IsIncluded(c.Query(config.IncludeQueryKey), config.IncludeSpecs) {
+		if err := h.vehicleService.IncludeModelSpecs(c.Request.Context(), &vehicleDetails); err != nil {
+			log.Printf("GetVehiclePlateNumber: model specs lookup failed for %s: %v", licensePlate, err)
+		}
+	}
+
+	utils.LocalizeVehicleResponse(&vehicleDetails, utils.ParseLanguage(c.GetHeader("Accept-Language")))
+
 	c.IndentedJSON(http.StatusOK, vehicleDetails)
 }
 
-func GetTirePressure(c *gin.Context) {
+func (h *VehicleHandler) GetVehiclesBatch(c *gin.Context) {
 	if !utils.IsRequestFromMobile(c.Request.UserAgent()) {
 		utils.RespondWithError(
 			c,
@@ -51,23 +93,143 @@ func GetTirePressure(c *gin.Context) {
 		return
 	}
 
-	licensePlate := c.Param(config.LicensePlateKey)
-	if licensePlate == "" {
+	var batchRequest vehicle.BatchVehicleRequest
+	if err := c.ShouldBindJSON(&batchRequest); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %v", serrors.ErrInvalidVehicleDetails, err))
+		return
+	}
+
+	if len(batchRequest.LicensePlates) == 0 {
 		utils.RespondWithError(
 			c,
 			http.StatusBadRequest,
-			fmt.Errorf("%w: license plate missing from request", serrors.ErrInvalidVehicleDetails),
+			fmt.Errorf("%w: license plates missing from request", serrors.ErrInvalidVehicleDetails),
+		)
+		return
+	}
+
+	if len(batchRequest.LicensePlates) > config.BatchMaxLicensePlates {
+		utils.RespondWithError(
+			c,
+			http.StatusBadRequest,
+			fmt.Errorf("%w: at most %d license plates are allowed per batch", serrors.ErrInvalidVehicleDetails, config.BatchMaxLicensePlates),
+		)
+		return
+	}
+
+	lookupResults := h.vehicleService.FetchVehicleDetailsBatch(c.Request.Context(), batchRequest.LicensePlates, config.BatchLookupConcurrency)
+
+	batchResponse := vehicle.BatchVehicleResponse{Results: make([]vehicle.BatchVehicleResult, 0, len(lookupResults))}
+	for _, lookupResult := range lookupResults {
+		result := vehicle.BatchVehicleResult{LicensePlate: lookupResult.LicensePlate, Status: http.StatusOK}
+		if lookupResult.Err != nil {
+			result.Status = utils.VehicleDetailsErrorStatus(lookupResult.Err)
+			result.Error = lookupResult.Err.Error()
+
+			var scrappedErr *vehicle.ScrappedVehicleError
+			if errors.As(lookupResult.Err, &scrappedErr) {
+				result.VehicleStatus = scrappedErr.Status
+				result.RemovalDate = scrappedErr.RemovalDate
+			}
+		} else {
+			vehicleDetails := lookupResult.VehicleDetails
+			result.Vehicle = &vehicleDetails
+		}
+		batchResponse.Results = append(batchResponse.Results, result)
+	}
+
+	c.IndentedJSON(http.StatusOK, batchResponse)
+}
+
+func (h *VehicleHandler) SearchVehicles(c *gin.Context) {
+	if !utils.IsRequestFromMobile(c.Request.UserAgent()) {
+		utils.RespondWithError(
+			c,
+			http.StatusBadRequest,
+			fmt.Errorf("%w: request is not from a mobile device", serrors.ErrInvalidVehicleDetails),
 		)
 		return
 	}
 
-	vehicleDetails, err := services.FetchVehicleDetailsByLicensePlate(licensePlate)
+	criteria := services.VehicleSearchCriteria{
+		Manufacturer:   c.Query("manufacturer"),
+		CommercialName: c.Query("commercial_name"),
+		Color:          c.Query("color"),
+		FuelType:       c.Query("fuel_type"),
+		Limit:          config.VehicleSearchDefaultLimit,
+	}
+
+	intParams := []struct {
+		key   string
+		value *int
+	}{
+		{"year_from", &criteria.YearFrom},
+		{"year_to", &criteria.YearTo},
+		{"limit", &criteria.Limit},
+		{"offset", &criteria.Offset},
+	}
+	for _, param := range intParams {
+		raw := c.Query(param.key)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			utils.RespondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: %s must be a non-negative number", serrors.ErrInvalidVehicleDetails, param.key))
+			return
+		}
+		*param.value = value
+	}
+
+	if criteria.Limit < 1 || criteria.Limit > config.VehicleSearchMaxLimit {
+		utils.RespondWithError(c, http.StatusBadRequest, fmt.Errorf("%w: limit must be between 1 and %d", serrors.ErrInvalidVehicleDetails, config.VehicleSearchMaxLimit))
+		return
+	}
+
+	searchResponse, err := h.vehicleService.SearchVehicles(c.Request.Context(), criteria)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, "")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, searchResponse)
+}
+
+func (h *VehicleHandler) GetTirePressure(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	unit, err := services.ParseTirePressureUnit(c.Query(config.PressureUnitQueryKey))
 	if err != nil {
 		utils.HandleVehicleDetailsError(c, err, licensePlate)
 		return
 	}
 
-	tirePressureResponse, err := services.FetchTirePressureByVehicleDetails(vehicleDetails)
+	region, err := services.ParseWheelSizeRegion(c.Query(config.RegionQueryKey))
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	debug := false
+	if debugQuery := c.Query(config.DebugQueryKey); debugQuery != "" {
+		debug, err = strconv.ParseBool(debugQuery)
+		if err != nil {
+			utils.HandleVehicleDetailsError(c, fmt.Errorf("%w: invalid debug value %q", serrors.ErrInvalidVehicleDetails, debugQuery), licensePlate)
+			return
+		}
+	}
+
+	vehicleDetails, err := h.vehicleService.FetchVehicleDetailsByLicensePlate(c.Request.Context(), licensePlate)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	tirePressureOptions := services.TirePressureOptions{Unit: unit, Region: region, Debug: debug}
+	tirePressureResponse, err := h.tirePressureService.FetchTirePressure(c.Request.Context(), vehicleDetails, tirePressureOptions)
 	if err != nil {
 		utils.HandleVehicleDetailsError(c, err, licensePlate)
 		return
@@ -75,3 +237,81 @@ func GetTirePressure(c *gin.Context) {
 
 	c.IndentedJSON(http.StatusOK, tirePressureResponse)
 }
+
+func (h *VehicleHandler) GetDisabledPermit(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	permit, err := h.vehicleService.FetchDisabledPermitByLicensePlate(c.Request.Context(), licensePlate)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, permit)
+}
+
+func (h *VehicleHandler) GetOwnershipHistory(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	ownershipHistory, err := h.vehicleService.FetchOwnershipHistoryByLicensePlate(c.Request.Context(), licensePlate)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, ownershipHistory)
+}
+
+func (h *VehicleHandler) GetWheelFitments(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	unit, err := services.ParseTirePressureUnit(c.Query(config.PressureUnitQueryKey))
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	region, err := services.ParseWheelSizeRegion(c.Query(config.RegionQueryKey))
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	vehicleDetails, err := h.vehicleService.FetchVehicleDetailsByLicensePlate(c.Request.Context(), licensePlate)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	fitmentResponse, err := h.wheelFitmentService.FetchWheelFitments(c.Request.Context(), vehicleDetails, services.TirePressureOptions{Unit: unit, Region: region})
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, fitmentResponse)
+}
+
+func (h *VehicleHandler) GetRecalls(c *gin.Context) {
+	licensePlate, ok := licensePlateFromRequest(c)
+	if !ok {
+		return
+	}
+
+	recallsResponse, err := h.vehicleService.FetchOpenRecallsByLicensePlate(c.Request.Context(), licensePlate)
+	if err != nil {
+		utils.HandleVehicleDetailsError(c, err, licensePlate)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, recallsResponse)
+}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"log"
+	"net/http"
+	"os"
+	"time"
 
+	config "car-license-number-fetcher/config"
 	"car-license-number-fetcher/handlers"
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/services"
 	"car-license-number-fetcher/utils"
 
 	"github.com/gin-gonic/gin"
@@ -13,9 +19,72 @@ func main() {
 	router := gin.Default()
 	router.SetTrustedProxies(nil)
 
-	router.GET("/vehicle/:licensePlate", handlers.GetVehiclePlateNumber)
+	ckanClient := services.NewCKANClient(
+		utils.GetVehicleDataAPIEndpoint(),
+		&http.Client{Timeout: config.CKANRequestTimeoutSeconds * time.Second},
+	)
+
+	var vehicleDataSource services.VehicleDataSource = services.NewFallbackVehicleDataSource(
+		services.NewCKANVehicleDataSource(ckanClient, config.VehicleResourceID),
+		services.NewCKANMotorcycleDataSource(ckanClient, config.MotorcycleResourceID),
+		services.NewCKANHeavyVehicleDataSource(ckanClient, config.HeavyVehicleResourceID),
+		services.NewCKANPersonalImportDataSource(ckanClient, config.PersonalImportResourceID),
+		services.NewCKANDeregisteredVehicleDataSource(ckanClient, config.InactiveVehicleResourceID, vehicle.VehicleStatusInactive),
+		services.NewCKANDeregisteredVehicleDataSource(ckanClient, config.ScrappedVehicleResourceID, vehicle.VehicleStatusScrapped),
+	)
+	if snapshotPath := os.Getenv(config.VehicleSnapshotPathEnvVar); snapshotPath != "" {
+		snapshot, err := services.OpenVehicleSnapshot(snapshotPath)
+		if err != nil {
+			log.Fatalf("Loading vehicle snapshot encountered an error: %s", err)
+		}
+		defer snapshot.Close()
+		log.Printf("Serving %d vehicles from snapshot taken on %s", snapshot.RecordCount, snapshot.SnapshotDate)
+		vehicleDataSource = services.NewSnapshotVehicleDataSource(snapshot)
+	}
+
+	wheelSizeClient := services.NewWheelSizeClient(
+		&http.Client{Timeout: config.WheelSizeRequestTimeoutSeconds * time.Second},
+	)
+
+	var tirePressureProviders []services.TirePressureProvider
+	for _, providerName := range utils.GetTirePressureProviders() {
+		switch providerName {
+		case config.TirePressureProviderWheelSize:
+			tirePressureProviders = append(tirePressureProviders, services.NewWheelSizeTirePressureProvider(wheelSizeClient))
+		case config.TirePressureProviderLocalTable:
+			tablePath := os.Getenv(config.TirePressureTablePathEnvVar)
+			if tablePath == "" {
+				log.Printf("Skipping tire pressure provider %s: %s is not set", providerName, config.TirePressureTablePathEnvVar)
+				continue
+			}
+			entries, err := services.ReadTirePressureTable(tablePath)
+			if err != nil {
+				log.Fatalf("Loading tire pressure table encountered an error: %s", err)
+			}
+			tirePressureProviders = append(tirePressureProviders, services.NewLocalTirePressureProvider(entries))
+		default:
+			log.Fatalf("Unknown tire pressure provider %q in %s", providerName, config.TirePressureProvidersEnvVar)
+		}
+	}
+	if len(tirePressureProviders) == 0 {
+		log.Fatalf("No tire pressure providers configured by %s", config.TirePressureProvidersEnvVar)
+	}
+
+	vehicleHandler := handlers.NewVehicleHandler(
+		services.NewVehicleService(vehicleDataSource, ckanClient),
+		services.NewTirePressureService(tirePressureProviders...),
+		services.NewWheelFitmentService(wheelSizeClient),
+	)
+
+	router.GET("/vehicle/:licensePlate", vehicleHandler.GetVehiclePlateNumber)
+	router.GET("/vehicle/:licensePlate/ownership-history", vehicleHandler.GetOwnershipHistory)
+	router.POST("/vehicles/batch", vehicleHandler.GetVehiclesBatch)
+	router.GET("/vehicles/search", vehicleHandler.SearchVehicles)
 	router.GET("/review/:vehicleName", handlers.GetVehicleReview)
-	router.GET("/tire-pressure/:licensePlate", handlers.GetTirePressure)
+	router.GET("/tire-pressure/:licensePlate", vehicleHandler.GetTirePressure)
+	router.GET("/wheels/:licensePlate", vehicleHandler.GetWheelFitments)
+	router.GET("/recalls/:licensePlate", vehicleHandler.GetRecalls)
+	router.GET("/disabled-permit/:licensePlate", vehicleHandler.GetDisabledPermit)
 
 	port := utils.GetPort()
 
@@ -0,0 +1,23 @@
+package vehicle
+
+// BatchVehicleRequest is the body of a batch vehicle lookup
+type BatchVehicleRequest struct {
+	LicensePlates []string `json:"license_plates"`
+}
+
+// BatchVehicleResult is the outcome of looking up one plate of a batch. Status
+// is the HTTP status the plate would have been answered with on its own.
+// Scrapped plates also carry their VehicleStatus and RemovalDate.
+type BatchVehicleResult struct {
+	LicensePlate  string           `json:"license_plate"`
+	Status        int              `json:"status"`
+	Vehicle       *VehicleResponse `json:"vehicle,omitempty"`
+	Error         string           `json:"error,omitempty"`
+	VehicleStatus string           `json:"vehicle_status,omitempty"`
+	RemovalDate   *Date            `json:"removal_date,omitempty"`
+}
+
+// BatchVehicleResponse holds the batch results in request order
+type BatchVehicleResponse struct {
+	Results []BatchVehicleResult `json:"results"`
+}
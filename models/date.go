@@ -0,0 +1,38 @@
+package vehicle
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Date is a calendar date serialized in ISO 8601 form (YYYY-MM-DD)
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the Date of t, or nil for the zero time so missing registry dates serialize as null
+func NewDate(t time.Time) *Date {
+	if t.IsZero() {
+		return nil
+	}
+	return &Date{Time: t}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(time.DateOnly))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return err
+	}
+
+	d.Time = parsed
+	return nil
+}
@@ -0,0 +1,19 @@
+package vehicle
+
+// LabeledValue is a registry value decoded into a stable English enum value
+// alongside the label the registry published it with and, when the request
+// asked for a language, its display name in that language
+type LabeledValue struct {
+	Value     string `json:"value"`
+	Label     string `json:"label"`
+	Localized string `json:"localized,omitempty"`
+}
+
+// DecodedFields holds the coded registry fields of a vehicle as labeled enums
+type DecodedFields struct {
+	FuelType            *LabeledValue `json:"fuel_type,omitempty"`
+	Ownership           *LabeledValue `json:"ownership,omitempty"`
+	Color               *LabeledValue `json:"color,omitempty"`
+	PollutionLevel      *LabeledValue `json:"pollution_level,omitempty"`
+	SafetyFeaturesLevel *LabeledValue `json:"safety_feature_level,omitempty"`
+}
@@ -0,0 +1,35 @@
+package vehicle
+
+import (
+	"fmt"
+	"time"
+
+	serrors "car-license-number-fetcher/serrors"
+)
+
+// DeregisteredVehicleRecord is a row of the data.gov.il inactive or scrapped
+// vehicle resources, which share the main registry columns plus the date the
+// vehicle was taken off the road
+type DeregisteredVehicleRecord struct {
+	VehicleRecord
+	RemovalDate string `json:"bitul_dt"`
+}
+
+// ScrappedVehicleError reports a plate found in the scrapped vehicle resource.
+// Its status and removal date are returned next to the error message.
+type ScrappedVehicleError struct {
+	LicensePlate string `json:"-"`
+	Status       string `json:"status"`
+	RemovalDate  *Date  `json:"removal_date,omitempty"`
+}
+
+func (e *ScrappedVehicleError) Error() string {
+	if e.RemovalDate == nil {
+		return fmt.Sprintf("%s: license plate %s was taken off the road", serrors.ErrVehicleScrapped, e.LicensePlate)
+	}
+	return fmt.Sprintf("%s: license plate %s was taken off the road on %s", serrors.ErrVehicleScrapped, e.LicensePlate, e.RemovalDate.Format(time.DateOnly))
+}
+
+func (e *ScrappedVehicleError) Unwrap() error {
+	return serrors.ErrVehicleScrapped
+}
@@ -0,0 +1,17 @@
+package vehicle
+
+// DisabledPermitRecord is a row of the data.gov.il disabled parking permit (tav nacheh) resource
+type DisabledPermitRecord struct {
+	ID            int    `json:"_id"`
+	LicenseNumber int    `json:"MISPAR RECHEV"`
+	IssueDate     string `json:"TAARICH HAFAKAT TAG"`
+	PermitType    any    `json:"SUG TAV"`
+}
+
+// DisabledPermitResponse reports whether a license plate holds a disabled parking permit
+type DisabledPermitResponse struct {
+	LicenseNumber     int    `json:"license_plate_number"`
+	HasDisabledPermit bool   `json:"has_disabled_permit"`
+	IssueDate         *Date  `json:"issue_date,omitempty"`
+	PermitType        string `json:"permit_type,omitempty"`
+}
@@ -0,0 +1,37 @@
+package vehicle
+
+// HeavyVehicleRecord is a row of the data.gov.il registry resource for
+// vehicles over 3.5 ton such as trucks and buses
+type HeavyVehicleRecord struct {
+	ID                  int     `json:"_id"`
+	LicenseNumber       int     `json:"mispar_rechev"`
+	ProductionCountry   int     `json:"tozeret_cd"`
+	ManufacturerName    string  `json:"tozeret_nm"`
+	ManufacturerCountry string  `json:"tozeret_eretz_nm"`
+	ModelSerialNumber   int     `json:"degem_cd"`
+	ModelCode           string  `json:"degem_nm"`
+	CommercialName      string  `json:"kinuy_mishari"`
+	VehicleType         string  `json:"sug_rechev_nm"`
+	ManufacturYear      int     `json:"shnat_yitzur"`
+	TotalWeight         int     `json:"mishkal_kolel"`
+	AxleCount           int     `json:"mispar_tzirim"`
+	CarryingCapacity    int     `json:"kosher_neshia"`
+	FuelType            string  `json:"sug_delek_nm"`
+	LastTestDate        string  `json:"mivchan_acharon_dt"`
+	ValidDate           string  `json:"tokef_dt"`
+	Ownership           string  `json:"baalut"`
+	FrameNumber         string  `json:"misgeret"`
+	Color               string  `json:"tzeva_rechev"`
+	FrontWheel          string  `json:"zmig_kidmi"`
+	RearWheel           string  `json:"zmig_ahori"`
+	FirstOnRoadDate     string  `json:"moed_aliya_lakvish"`
+	Rank                float64 `json:"rank"`
+}
+
+// HeavyVehicleDetails holds the fields only the heavy vehicle registry provides
+type HeavyVehicleDetails struct {
+	VehicleType      string `json:"vehicle_type"`
+	TotalWeight      int    `json:"total_weight_kg"`
+	AxleCount        int    `json:"axle_count"`
+	CarryingCapacity int    `json:"carrying_capacity_kg"`
+}
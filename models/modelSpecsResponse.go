@@ -0,0 +1,32 @@
+package vehicle
+
+// ModelSpecsRecord is a row of the data.gov.il model specification (WLTP) resource
+type ModelSpecsRecord struct {
+	ID                      int     `json:"_id"`
+	ProductionCountry       int     `json:"tozeret_cd"`
+	ModelSerialNumber       int     `json:"degem_cd"`
+	ManufacturYear          int     `json:"shnat_yitzur"`
+	EngineSerialNumber      string  `json:"degem_manoa"`
+	EngineVolume            int     `json:"nefah_manoa"`
+	Horsepower              int     `json:"koah_sus"`
+	FuelConsumptionUrban    float64 `json:"tzrihat_delek_ironit"`
+	FuelConsumptionExtra    float64 `json:"tzrihat_delek_bein_ironit"`
+	FuelConsumptionCombined float64 `json:"tzrihat_delek_meshulevet"`
+	CO2                     float64 `json:"kamut_CO2"`
+	Seats                   int     `json:"mispar_moshavim"`
+	DriveType               string  `json:"hanaa_nm"`
+	TotalWeight             int     `json:"mishkal_kolel"`
+}
+
+// ModelSpecs holds the technical specifications shared by all vehicles of a model
+type ModelSpecs struct {
+	EngineVolume            int     `json:"engine_volume_cc,omitempty"`
+	Horsepower              int     `json:"horsepower,omitempty"`
+	FuelConsumptionUrban    float64 `json:"fuel_consumption_urban_l_100km,omitempty"`
+	FuelConsumptionExtra    float64 `json:"fuel_consumption_extra_urban_l_100km,omitempty"`
+	FuelConsumptionCombined float64 `json:"fuel_consumption_combined_l_100km,omitempty"`
+	CO2                     float64 `json:"co2_g_km,omitempty"`
+	Seats                   int     `json:"seats,omitempty"`
+	DriveType               string  `json:"drive_type,omitempty"`
+	TotalWeight             int     `json:"total_weight_kg,omitempty"`
+}
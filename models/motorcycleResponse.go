@@ -0,0 +1,29 @@
+package vehicle
+
+// MotorcycleRecord is a row of the data.gov.il two-wheeler registry resource
+type MotorcycleRecord struct {
+	ID                  int     `json:"_id"`
+	LicenseNumber       int     `json:"mispar_rechev"`
+	ProductionCountry   int     `json:"tozeret_cd"`
+	ManufacturerName    string  `json:"tozeret_nm"`
+	ManufacturerCountry string  `json:"tozeret_eretz_nm"`
+	ModelSerialNumber   int     `json:"degem_cd"`
+	ModelCode           string  `json:"degem_nm"`
+	CommercialName      string  `json:"kinuy_mishari"`
+	ManufacturYear      int     `json:"shnat_yitzur"`
+	EngineDisplacement  int     `json:"nefach_manoa"`
+	Power               float64 `json:"hespek"`
+	FuelType            string  `json:"sug_delek_nm"`
+	LastTestDate        string  `json:"mivchan_acharon_dt"`
+	ValidDate           string  `json:"tokef_dt"`
+	Ownership           string  `json:"baalut"`
+	FrameNumber         string  `json:"misgeret"`
+	FirstOnRoadDate     string  `json:"moed_aliya_lakvish"`
+	Rank                float64 `json:"rank"`
+}
+
+// MotorcycleDetails holds the fields only the two-wheeler registry provides
+type MotorcycleDetails struct {
+	EngineDisplacement int     `json:"engine_displacement_cc"`
+	Power              float64 `json:"power_kw"`
+}
@@ -0,0 +1,25 @@
+package vehicle
+
+// OwnershipHistoryRecord is a row of the data.gov.il ownership history resource.
+// OwnershipDate is the month the ownership started, encoded as YYYYMM.
+type OwnershipHistoryRecord struct {
+	ID            int    `json:"_id"`
+	LicenseNumber int    `json:"mispar_rechev"`
+	OwnershipDate int    `json:"baalut_dt"`
+	Ownership     string `json:"baalut"`
+}
+
+// OwnershipPeriod is a single ownership of a vehicle, dated by the first day of
+// the months it started and ended. EndDate is nil for the current ownership.
+type OwnershipPeriod struct {
+	Ownership     string `json:"ownership"`
+	OwnershipType string `json:"ownership_type"`
+	StartDate     *Date  `json:"start_date"`
+	EndDate       *Date  `json:"end_date,omitempty"`
+}
+
+// OwnershipHistoryResponse lists the ownership periods of a vehicle from oldest to newest
+type OwnershipHistoryResponse struct {
+	LicenseNumber int               `json:"license_plate_number"`
+	Periods       []OwnershipPeriod `json:"periods"`
+}
@@ -0,0 +1,22 @@
+package vehicle
+
+// PersonalImportRecord is a row of the data.gov.il personal import vehicle resource
+type PersonalImportRecord struct {
+	ID                  int     `json:"_id"`
+	LicenseNumber       int     `json:"mispar_rechev"`
+	FrameNumber         string  `json:"shilda"`
+	ProductionCountry   int     `json:"tozeret_cd"`
+	ManufacturerName    string  `json:"tozeret_nm"`
+	ManufacturerCountry string  `json:"tozeret_eretz_nm"`
+	ModelSerialNumber   int     `json:"degem_cd"`
+	ModelCode           string  `json:"degem_nm"`
+	CommercialName      string  `json:"kinuy_mishari"`
+	EngineSerialNumber  string  `json:"degem_manoa"`
+	ManufacturYear      int     `json:"shnat_yitzur"`
+	FuelType            string  `json:"sug_delek_nm"`
+	Color               string  `json:"tzeva_rechev"`
+	LastTestDate        string  `json:"mivchan_acharon_dt"`
+	ValidDate           string  `json:"tokef_dt"`
+	FirstOnRoadDate     string  `json:"moed_aliya_lakvish"`
+	Rank                float64 `json:"rank"`
+}
@@ -0,0 +1,32 @@
+package vehicle
+
+// RecallRecord is a row of the Ministry of Transport recall campaigns resource
+type RecallRecord struct {
+	ID                int    `json:"_id"`
+	RecallID          string `json:"recall_id"`
+	ProductionCountry int    `json:"tozeret_cd"`
+	ModelSerialNumber int    `json:"degem_cd"`
+	FromYear          int    `json:"shnat_yitzur_mi"`
+	ToYear            int    `json:"shnat_yitzur_ad"`
+	Description       string `json:"teur_takala"`
+	Remedy            string `json:"ofen_tikun"`
+	OpenDate          string `json:"tarich_pticha"`
+	CloseDate         string `json:"tarich_sgira"`
+}
+
+// Recall is a single recall campaign affecting a vehicle
+type Recall struct {
+	RecallID    string `json:"recall_id"`
+	Description string `json:"description"`
+	Remedy      string `json:"remedy,omitempty"`
+	OpenDate    *Date  `json:"open_date"`
+}
+
+// RecallsResponse lists the open recall campaigns for a license plate
+type RecallsResponse struct {
+	LicenseNumber     int      `json:"license_plate_number"`
+	ProductionCountry int      `json:"production_country"`
+	ModelSerialNumber int      `json:"model_serial_number"`
+	ManufacturYear    int      `json:"year_of_production"`
+	Recalls           []Recall `json:"recalls"`
+}
@@ -1,10 +1,45 @@
 package vehicle
 
+const (
+	TirePressureMatchExact = "exact"
+	TirePressureMatchStock = "stock_fallback"
+	TirePressureMatchFirst = "first_entry_fallback"
+	TirePressureMatchModel = "model_fallback"
+
+	PressureUnitPsi = "psi"
+	PressureUnitBar = "bar"
+	PressureUnitKPa = "kPa"
+	PressureUnitAll = "all"
+)
+
+// AxlePressure is the tire pressure of one axle in the requested units
+type AxlePressure struct {
+	Psi *float64 `json:"psi,omitempty"`
+	Bar *float64 `json:"bar,omitempty"`
+	KPa *float64 `json:"kPa,omitempty"`
+}
+
+// WheelSizeResolution reports the wheel-size catalogue slugs the registry make
+// and model were matched to, with a confidence between 0 and 1 for each
+type WheelSizeResolution struct {
+	MakeSlug        string  `json:"makeSlug"`
+	ModelSlug       string  `json:"modelSlug"`
+	MakeConfidence  float64 `json:"makeConfidence"`
+	ModelConfidence float64 `json:"modelConfidence"`
+}
+
 type TirePressureResponse struct {
-	Source   string      `json:"source"`
-	FrontPsi *float64    `json:"frontPsi,omitempty"`
-	RearPsi  *float64    `json:"rearPsi,omitempty"`
-	Unit     string      `json:"unit,omitempty"`
-	Note     string      `json:"note,omitempty"`
-	Raw      interface{} `json:"raw,omitempty"`
+	Source       string               `json:"source"`
+	FrontPsi     *float64             `json:"frontPsi,omitempty"`
+	RearPsi      *float64             `json:"rearPsi,omitempty"`
+	Front        *AxlePressure        `json:"front,omitempty"`
+	Rear         *AxlePressure        `json:"rear,omitempty"`
+	FrontMaxLoad *AxlePressure        `json:"frontMaxLoad,omitempty"`
+	RearMaxLoad  *AxlePressure        `json:"rearMaxLoad,omitempty"`
+	Unit         string               `json:"unit,omitempty"`
+	Match        string               `json:"match,omitempty"`
+	Region       string               `json:"region,omitempty"`
+	Resolution   *WheelSizeResolution `json:"resolution,omitempty"`
+	Note         string               `json:"note,omitempty"`
+	Raw          interface{}          `json:"raw,omitempty"`
 }
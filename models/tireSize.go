@@ -0,0 +1,15 @@
+package vehicle
+
+// TireSize is a parsed tire size marking such as "205/55 R16 91V". LoadKg and
+// MaxSpeedKmh translate the load index and speed rating when they are present.
+type TireSize struct {
+	Raw          string  `json:"raw"`
+	Width        int     `json:"width_mm"`
+	AspectRatio  int     `json:"aspect_ratio,omitempty"`
+	Construction string  `json:"construction"`
+	RimDiameter  float64 `json:"rim_diameter_in"`
+	LoadIndex    int     `json:"load_index,omitempty"`
+	LoadKg       int     `json:"load_kg,omitempty"`
+	SpeedRating  string  `json:"speed_rating,omitempty"`
+	MaxSpeedKmh  int     `json:"max_speed_kmh,omitempty"`
+}
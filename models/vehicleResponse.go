@@ -1,16 +1,29 @@
 package vehicle
 
-type VehicleDetails struct {
+const (
+	VehicleCategoryCar        = "car"
+	VehicleCategoryMotorcycle = "motorcycle"
+	VehicleCategoryHeavy      = "heavy"
+
+	VehicleStatusActive   = "active"
+	VehicleStatusInactive = "inactive"
+	VehicleStatusScrapped = "scrapped"
+
+	ImportTypePersonal = "personal"
+)
+
+// CKANResponse is the envelope the CKAN datastore_search action wraps records in
+type CKANResponse[T any] struct {
 	Help    string `json:"help"`
 	Success bool   `json:"success"`
 	Result  struct {
-		IncludeTotal             bool            `json:"include_total"`
-		Limit                    int             `json:"limit"`
-		Q                        string          `json:"q"`
-		RecordsFormat            string          `json:"records_format"`
-		ResourceID               string          `json:"resource_id"`
-		TotalEstimationThreshold any             `json:"total_estimation_threshold"`
-		Records                  []VehicleRecord `json:"records"`
+		IncludeTotal             bool   `json:"include_total"`
+		Limit                    int    `json:"limit"`
+		Q                        string `json:"q"`
+		RecordsFormat            string `json:"records_format"`
+		ResourceID               string `json:"resource_id"`
+		TotalEstimationThreshold any    `json:"total_estimation_threshold"`
+		Records                  []T    `json:"records"`
 		Fields                   []struct {
 			ID   string `json:"id"`
 			Type string `json:"type"`
@@ -24,6 +37,8 @@ type VehicleDetails struct {
 	} `json:"result"`
 }
 
+type VehicleDetails = CKANResponse[VehicleRecord]
+
 type VehicleRecord struct {
 	ID                   int     `json:"_id"`
 	LicenseNumber        int     `json:"mispar_rechev"`
@@ -54,21 +69,43 @@ type VehicleRecord struct {
 
 // VehicleResponse represents the structured response for a vehicle
 type VehicleResponse struct {
-	LicenseNumber       int    `json:"license_plate_number"`
-	ManufacturerCountry string `json:"manufacturer_country"`
-	TrimLevel           string `json:"trim_level"`
-	SafetyFeaturesLevel any    `json:"safety_feature_level"`
-	PollutionLevel      int    `json:"pollution_level"`
-	ManufacturYear      int    `json:"year_of_production"`
-	LastTestDate        string `json:"last_test_date"`
-	ValidDate           string `json:"valid_date"`
-	Ownership           string `json:"ownership"`
-	FrameNumber         string `json:"frame_number"`
-	Color               string `json:"color"`
-	FrontWheel          string `json:"front_wheel"`
-	RearWheel           string `json:"rear_wheel"`
-	FuelType            string `json:"fuel_type"`
-	FirstOnRoadDate     string `json:"first_on_road_date"`
-	CommercialName      string `json:"commercial_name"`
-	ManufacturerName    string `json:"manufacturer_name"`
+	LicenseNumber       int                  `json:"license_plate_number"`
+	LicensePlate        string               `json:"license_plate"`
+	LicensePlateDisplay string               `json:"license_plate_display"`
+	ManufacturerCountry string               `json:"manufacturer_country"`
+	TrimLevel           string               `json:"trim_level"`
+	SafetyFeaturesLevel any                  `json:"safety_feature_level"`
+	PollutionLevel      int                  `json:"pollution_level"`
+	ManufacturYear      int                  `json:"year_of_production"`
+	LastTestDate        *Date                `json:"last_test_date"`
+	ValidDate           *Date                `json:"valid_date"`
+	DaysUntilTestExpiry *int                 `json:"days_until_test_expiry"`
+	TestExpired         *bool                `json:"test_expired"`
+	Ownership           string               `json:"ownership"`
+	FrameNumber         string               `json:"frame_number"`
+	Color               string               `json:"color"`
+	FrontWheel          string               `json:"front_wheel"`
+	RearWheel           string               `json:"rear_wheel"`
+	FrontWheelSpec      *TireSize            `json:"front_wheel_spec,omitempty"`
+	RearWheelSpec       *TireSize            `json:"rear_wheel_spec,omitempty"`
+	FuelType            string               `json:"fuel_type"`
+	FirstOnRoadDate     *Date                `json:"first_on_road_date"`
+	VehicleAgeYears     *int                 `json:"vehicle_age_years"`
+	CommercialName      string               `json:"commercial_name"`
+	ManufacturerName    string               `json:"manufacturer_name"`
+	SnapshotDate        string               `json:"snapshot_date,omitempty"`
+	VehicleCategory     string               `json:"vehicle_category"`
+	Motorcycle          *MotorcycleDetails   `json:"motorcycle,omitempty"`
+	HeavyVehicle        *HeavyVehicleDetails `json:"heavy_vehicle,omitempty"`
+	Status              string               `json:"status"`
+	RemovalDate         *Date                `json:"removal_date,omitempty"`
+	ProductionCountry   int                  `json:"production_country"`
+	ModelSerialNumber   int                  `json:"model_serial_number"`
+	EngineSerialNumber  string               `json:"engine_serial_number,omitempty"`
+	Specs               *ModelSpecs          `json:"specs,omitempty"`
+	HasDisabledPermit   *bool                `json:"has_disabled_permit,omitempty"`
+	ImportType          string               `json:"import_type,omitempty"`
+	Decoded             *DecodedFields       `json:"decoded,omitempty"`
+	Language            string               `json:"language,omitempty"`
+	Labels              map[string]string    `json:"labels,omitempty"`
 }
@@ -0,0 +1,11 @@
+package vehicle
+
+// VehicleSearchResponse is a page of registry vehicles matching a search.
+// Total is the number of matches CKAN reports for the whole search and
+// NextOffset is set while more matches remain.
+type VehicleSearchResponse struct {
+	Total      int               `json:"total"`
+	Offset     int               `json:"offset"`
+	NextOffset *int              `json:"next_offset,omitempty"`
+	Results    []VehicleResponse `json:"results"`
+}
@@ -0,0 +1,32 @@
+package vehicle
+
+// AxleFitment is the tire and rim fitted to one axle
+type AxleFitment struct {
+	Tire            string        `json:"tire"`
+	TireFull        string        `json:"tireFull,omitempty"`
+	Rim             string        `json:"rim,omitempty"`
+	RimDiameter     float64       `json:"rimDiameter,omitempty"`
+	RimWidth        float64       `json:"rimWidth,omitempty"`
+	RimOffset       float64       `json:"rimOffset,omitempty"`
+	Pressure        *AxlePressure `json:"pressure,omitempty"`
+	PressureMaxLoad *AxlePressure `json:"pressureMaxLoad,omitempty"`
+}
+
+// WheelFitment is one OEM or optional wheel configuration of a model. Rear is
+// omitted when both axles share the front fitment.
+type WheelFitment struct {
+	IsStock bool         `json:"isStock"`
+	Front   AxleFitment  `json:"front"`
+	Rear    *AxleFitment `json:"rear,omitempty"`
+}
+
+// WheelFitmentResponse lists every wheel and tire fitment of the vehicle's model
+type WheelFitmentResponse struct {
+	Source      string               `json:"source"`
+	Model       string               `json:"model,omitempty"`
+	BoltPattern string               `json:"boltPattern,omitempty"`
+	CenterBore  string               `json:"centerBore,omitempty"`
+	Region      string               `json:"region,omitempty"`
+	Resolution  *WheelSizeResolution `json:"resolution,omitempty"`
+	Fitments    []WheelFitment       `json:"fitments"`
+}
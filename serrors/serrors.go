@@ -11,4 +11,8 @@ var (
     ErrFetchTirePressure          = errors.New("fetch tire pressure")
     ErrNoTirePressureData         = errors.New("no tire pressure data")
     ErrInvalidVehicleDetails      = errors.New("invalid vehicle details")
+    ErrLoadSnapshot               = errors.New("load snapshot")
+    ErrVehicleScrapped            = errors.New("vehicle scrapped")
+    ErrAmbiguousVehicle           = errors.New("ambiguous vehicle")
+    ErrLoadTirePressureTable      = errors.New("load tire pressure table")
 )
\ No newline at end of file
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+// VehicleLookupResult is the outcome of one lookup of a batch
+type VehicleLookupResult struct {
+	LicensePlate   string
+	VehicleDetails vehicle.VehicleResponse
+	Err            error
+}
+
+// FetchVehicleDetailsBatch looks up every plate with at most concurrency
+// lookups in flight and returns the results in the order of licensePlates
+func (s *VehicleService) FetchVehicleDetailsBatch(ctx context.Context, licensePlates []string, concurrency int) []VehicleLookupResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]VehicleLookupResult, len(licensePlates))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, licensePlate := range licensePlates {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, licensePlate string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			vehicleDetails, err := s.FetchVehicleDetailsByLicensePlate(ctx, licensePlate)
+			results[i] = VehicleLookupResult{LicensePlate: licensePlate, VehicleDetails: vehicleDetails, Err: err}
+		}(i, licensePlate)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+)
+
+// CKANClient queries resources of the data.gov.il CKAN datastore by exact column values
+type CKANClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewCKANClient(endpoint string, httpClient *http.Client) *CKANClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &CKANClient{endpoint: endpoint, httpClient: httpClient}
+}
+
+// ckanQuery selects rows of a datastore resource. Filters match columns exactly,
+// a filter value that is a list matching any of its items, while FieldQuery runs
+// a full-text search within the given columns.
+type ckanQuery struct {
+	Filters    map[string]any
+	FieldQuery map[string]string
+	Limit      int
+	Offset     int
+}
+
+// searchURL builds a datastore_search request for the rows of resourceID selected by query
+func (c *CKANClient) searchURL(resourceID string, query ckanQuery) (string, error) {
+	params := url.Values{}
+	params.Set("resource_id", resourceID)
+	params.Set("limit", strconv.Itoa(query.Limit))
+
+	if len(query.Filters) > 0 {
+		encodedFilters, err := json.Marshal(query.Filters)
+		if err != nil {
+			return "", err
+		}
+		params.Set("filters", string(encodedFilters))
+	}
+
+	if len(query.FieldQuery) > 0 {
+		encodedQuery, err := json.Marshal(query.FieldQuery)
+		if err != nil {
+			return "", err
+		}
+		params.Set("q", string(encodedQuery))
+	}
+
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+
+	return c.endpoint + "?" + params.Encode(), nil
+}
+
+// resolveURL resolves a link returned by CKAN, such as Result.Links.Next, against the client endpoint
+func (c *CKANClient) resolveURL(link string) (string, error) {
+	base, err := url.Parse(c.endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	reference, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(reference).String(), nil
+}
+
+// searchCKAN returns up to limit rows of resourceID matching filters decoded as T
+func searchCKAN[T any](ctx context.Context, c *CKANClient, resourceID string, filters map[string]any, limit int) ([]T, error) {
+	requestUrl, err := c.searchURL(resourceID, ckanQuery{Filters: filters, Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("%w: error encoding filters: %v", serrors.ErrFetchLicensePlate, err)
+	}
+
+	return fetchCKANRecords[T](ctx, c.httpClient, requestUrl)
+}
+
+// searchCKANByLicensePlate returns the single row of resourceID whose
+// mispar_rechev equals licensePlate exactly. kind names the registry in errors.
+func searchCKANByLicensePlate[T any](ctx context.Context, c *CKANClient, resourceID string, licensePlate string, kind string, licenseNumberOf func(T) int) (T, error) {
+	var zero T
+
+	licenseNumber, err := strconv.Atoi(licensePlate)
+	if err != nil {
+		return zero, fmt.Errorf("%w: license plate %s is not numeric", serrors.ErrInvalidVehicleDetails, licensePlate)
+	}
+
+	// Ask for one more row than we accept so duplicates are detected rather than silently dropped
+	records, err := searchCKAN[T](ctx, c, resourceID, map[string]any{"mispar_rechev": licenseNumber}, 2)
+	if err != nil {
+		return zero, err
+	}
+
+	switch {
+	case len(records) == 0:
+		return zero, fmt.Errorf("%w: no matching %s for license plate %s", serrors.ErrNoMatchingVehicle, kind, licensePlate)
+	case len(records) > 1:
+		return zero, fmt.Errorf("%w: %d %s records match license plate %s", serrors.ErrAmbiguousVehicle, len(records), kind, licensePlate)
+	case licenseNumberOf(records[0]) != licenseNumber:
+		return zero, fmt.Errorf("%w: no matching %s for license plate %s", serrors.ErrNoMatchingVehicle, kind, licensePlate)
+	}
+
+	return records[0], nil
+}
+
+// fetchCKANRecords runs a datastore_search request and returns the records of
+// a successful response decoded as T
+func fetchCKANRecords[T any](ctx context.Context, httpClient *http.Client, requestUrl string) ([]T, error) {
+	v, err := fetchCKANResponse[T](ctx, httpClient, requestUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Result.Records, nil
+}
+
+// fetchCKANResponse runs a datastore_search request and returns the whole
+// envelope of a successful response, including its total and paging links
+func fetchCKANResponse[T any](ctx context.Context, httpClient *http.Client, requestUrl string) (vehicle.CKANResponse[T], error) {
+	var v vehicle.CKANResponse[T]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return v, fmt.Errorf("%w: error creating request: %v", serrors.ErrFetchLicensePlate, err)
+	}
+
+	res, requestError := httpClient.Do(req)
+	if requestError != nil {
+		return v, fmt.Errorf("%w: %v", serrors.ErrFetchLicensePlate, requestError)
+	}
+	defer res.Body.Close()
+
+	resBody, readingResponseError := io.ReadAll(res.Body)
+	if readingResponseError != nil {
+		return v, fmt.Errorf("%w: %v", serrors.ErrParseResponse, readingResponseError)
+	}
+
+	if convertingToJsonError := json.Unmarshal(resBody, &v); convertingToJsonError != nil {
+		return v, fmt.Errorf("%w: %v", serrors.ErrParseResponse, convertingToJsonError)
+	}
+
+	if !v.Success {
+		return v, fmt.Errorf("%w", serrors.ErrResponseNotSuccessful)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/utils"
+)
+
+// CKANDeregisteredVehicleDataSource looks up vehicles taken off the road in one
+// of the data.gov.il inactive or scrapped vehicle resources. status is the
+// VehicleStatus reported for the plates that resource holds.
+type CKANDeregisteredVehicleDataSource struct {
+	ckanClient *CKANClient
+	resourceID string
+	status     string
+}
+
+func NewCKANDeregisteredVehicleDataSource(ckanClient *CKANClient, resourceID string, status string) *CKANDeregisteredVehicleDataSource {
+	return &CKANDeregisteredVehicleDataSource{ckanClient: ckanClient, resourceID: resourceID, status: status}
+}
+
+func (s *CKANDeregisteredVehicleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	record, err := searchCKANByLicensePlate(ctx, s.ckanClient, s.resourceID, licensePlate, s.status+" vehicle", func(record vehicle.DeregisteredVehicleRecord) int {
+		return record.LicenseNumber
+	})
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+
+	removalDate := utils.RegistryDate(record.RemovalDate)
+	if s.status == vehicle.VehicleStatusScrapped {
+		return vehicle.VehicleResponse{}, &vehicle.ScrappedVehicleError{LicensePlate: licensePlate, Status: s.status, RemovalDate: removalDate}
+	}
+
+	vehicleDetails, err := buildVehicleResponse(record.VehicleRecord)
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+	vehicleDetails.Status = s.status
+	vehicleDetails.RemovalDate = removalDate
+
+	return vehicleDetails, nil
+}
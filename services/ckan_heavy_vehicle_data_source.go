@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/utils"
+)
+
+// CKANHeavyVehicleDataSource looks up heavy vehicles (over 3.5 ton) in the data.gov.il CKAN datastore
+type CKANHeavyVehicleDataSource struct {
+	ckanClient *CKANClient
+	resourceID string
+}
+
+func NewCKANHeavyVehicleDataSource(ckanClient *CKANClient, resourceID string) *CKANHeavyVehicleDataSource {
+	return &CKANHeavyVehicleDataSource{ckanClient: ckanClient, resourceID: resourceID}
+}
+
+func (s *CKANHeavyVehicleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	record, err := searchCKANByLicensePlate(ctx, s.ckanClient, s.resourceID, licensePlate, "heavy vehicle", func(record vehicle.HeavyVehicleRecord) int {
+		return record.LicenseNumber
+	})
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+
+	return buildHeavyVehicleResponse(record), nil
+}
+
+// buildHeavyVehicleResponse maps a heavy vehicle registry record onto the vehicle response
+func buildHeavyVehicleResponse(record vehicle.HeavyVehicleRecord) vehicle.VehicleResponse {
+	return vehicle.VehicleResponse{
+		LicenseNumber:       record.LicenseNumber,
+		ManufacturerCountry: strings.TrimSpace(record.ManufacturerCountry),
+		ManufacturYear:      record.ManufacturYear,
+		LastTestDate:        utils.RegistryDate(record.LastTestDate),
+		ValidDate:           utils.RegistryDate(record.ValidDate),
+		Ownership:           record.Ownership,
+		FrameNumber:         record.FrameNumber,
+		Color:               record.Color,
+		FrontWheel:          record.FrontWheel,
+		RearWheel:           record.RearWheel,
+		FuelType:            record.FuelType,
+		FirstOnRoadDate:     utils.RegistryDate(record.FirstOnRoadDate),
+		CommercialName:      record.CommercialName,
+		ManufacturerName:    strings.TrimSpace(record.ManufacturerName),
+		VehicleCategory:     vehicle.VehicleCategoryHeavy,
+		Status:              vehicle.VehicleStatusActive,
+		ProductionCountry:   record.ProductionCountry,
+		ModelSerialNumber:   record.ModelSerialNumber,
+		HeavyVehicle: &vehicle.HeavyVehicleDetails{
+			VehicleType:      record.VehicleType,
+			TotalWeight:      record.TotalWeight,
+			AxleCount:        record.AxleCount,
+			CarryingCapacity: record.CarryingCapacity,
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/utils"
+)
+
+// CKANMotorcycleDataSource looks up two-wheelers in the data.gov.il CKAN datastore
+type CKANMotorcycleDataSource struct {
+	ckanClient *CKANClient
+	resourceID string
+}
+
+func NewCKANMotorcycleDataSource(ckanClient *CKANClient, resourceID string) *CKANMotorcycleDataSource {
+	return &CKANMotorcycleDataSource{ckanClient: ckanClient, resourceID: resourceID}
+}
+
+func (s *CKANMotorcycleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	record, err := searchCKANByLicensePlate(ctx, s.ckanClient, s.resourceID, licensePlate, "motorcycle", func(record vehicle.MotorcycleRecord) int {
+		return record.LicenseNumber
+	})
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+
+	return buildMotorcycleResponse(record), nil
+}
+
+// buildMotorcycleResponse maps a two-wheeler registry record onto the vehicle response
+func buildMotorcycleResponse(record vehicle.MotorcycleRecord) vehicle.VehicleResponse {
+	return vehicle.VehicleResponse{
+		LicenseNumber:       record.LicenseNumber,
+		ManufacturerCountry: strings.TrimSpace(record.ManufacturerCountry),
+		ManufacturYear:      record.ManufacturYear,
+		LastTestDate:        utils.RegistryDate(record.LastTestDate),
+		ValidDate:           utils.RegistryDate(record.ValidDate),
+		Ownership:           record.Ownership,
+		FrameNumber:         record.FrameNumber,
+		FuelType:            record.FuelType,
+		FirstOnRoadDate:     utils.RegistryDate(record.FirstOnRoadDate),
+		CommercialName:      record.CommercialName,
+		ManufacturerName:    strings.TrimSpace(record.ManufacturerName),
+		VehicleCategory:     vehicle.VehicleCategoryMotorcycle,
+		Status:              vehicle.VehicleStatusActive,
+		ProductionCountry:   record.ProductionCountry,
+		ModelSerialNumber:   record.ModelSerialNumber,
+		Motorcycle: &vehicle.MotorcycleDetails{
+			EngineDisplacement: record.EngineDisplacement,
+			Power:              record.Power,
+		},
+	}
+}
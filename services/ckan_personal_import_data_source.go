@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/utils"
+)
+
+// CKANPersonalImportDataSource looks up privately imported vehicles in the data.gov.il CKAN datastore
+type CKANPersonalImportDataSource struct {
+	ckanClient *CKANClient
+	resourceID string
+}
+
+func NewCKANPersonalImportDataSource(ckanClient *CKANClient, resourceID string) *CKANPersonalImportDataSource {
+	return &CKANPersonalImportDataSource{ckanClient: ckanClient, resourceID: resourceID}
+}
+
+func (s *CKANPersonalImportDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	record, err := searchCKANByLicensePlate(ctx, s.ckanClient, s.resourceID, licensePlate, "personal import vehicle", func(record vehicle.PersonalImportRecord) int {
+		return record.LicenseNumber
+	})
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+
+	return buildPersonalImportResponse(record), nil
+}
+
+// buildPersonalImportResponse maps a personal import registry record onto the vehicle response
+func buildPersonalImportResponse(record vehicle.PersonalImportRecord) vehicle.VehicleResponse {
+	return vehicle.VehicleResponse{
+		LicenseNumber:       record.LicenseNumber,
+		ManufacturerCountry: strings.TrimSpace(record.ManufacturerCountry),
+		ManufacturYear:      record.ManufacturYear,
+		LastTestDate:        utils.RegistryDate(record.LastTestDate),
+		ValidDate:           utils.RegistryDate(record.ValidDate),
+		FrameNumber:         record.FrameNumber,
+		Color:               record.Color,
+		FuelType:            record.FuelType,
+		FirstOnRoadDate:     utils.RegistryDate(record.FirstOnRoadDate),
+		CommercialName:      record.CommercialName,
+		ManufacturerName:    strings.TrimSpace(record.ManufacturerName),
+		VehicleCategory:     vehicle.VehicleCategoryCar,
+		Status:              vehicle.VehicleStatusActive,
+		ProductionCountry:   record.ProductionCountry,
+		ModelSerialNumber:   record.ModelSerialNumber,
+		EngineSerialNumber:  record.EngineSerialNumber,
+		ImportType:          vehicle.ImportTypePersonal,
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+// CKANVehicleDataSource looks up vehicles in the data.gov.il CKAN datastore
+type CKANVehicleDataSource struct {
+	ckanClient *CKANClient
+	resourceID string
+}
+
+func NewCKANVehicleDataSource(ckanClient *CKANClient, resourceID string) *CKANVehicleDataSource {
+	return &CKANVehicleDataSource{ckanClient: ckanClient, resourceID: resourceID}
+}
+
+func (s *CKANVehicleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	record, err := searchCKANByLicensePlate(ctx, s.ckanClient, s.resourceID, licensePlate, "vehicle", func(record vehicle.VehicleRecord) int {
+		return record.LicenseNumber
+	})
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+
+	return buildVehicleResponse(record)
+}
+
+// buildVehicleResponse maps a registry record onto the response returned to clients
+func buildVehicleResponse(record vehicle.VehicleRecord) (vehicle.VehicleResponse, error) {
+	splitManufactureCountryCharacter := utils.GetSplitCharacter(record.ManufactureCountry)
+	manufacturerCountryAndName := strings.Split(record.ManufactureCountry, splitManufactureCountryCharacter)
+	if len(manufacturerCountryAndName) < 2 {
+		// Search results can include rows without a country part
+		manufacturerCountryAndName = append(manufacturerCountryAndName, "")
+	}
+
+	// A missing safety level is unknown rather than zero, so it is left unset
+	var safetyFeaturesLevel any
+	switch level := record.SafetyFeaturesLevel.(type) {
+	case string:
+		parsedLevel, conversionError := utils.ParseSafetyFeaturesLevelField(record)
+		if conversionError != nil {
+			return vehicle.VehicleResponse{}, fmt.Errorf("%w: %v", serrors.ErrConvertSafetyFeaturesLevel, conversionError)
+		}
+		safetyFeaturesLevel = parsedLevel
+	case float64:
+		safetyFeaturesLevel = int(level)
+	}
+
+	vehicleDetails := vehicle.VehicleResponse{
+		LicenseNumber:       record.LicenseNumber,
+		ManufacturerCountry: manufacturerCountryAndName[1],
+		TrimLevel:           record.TrimLevel,
+		SafetyFeaturesLevel: safetyFeaturesLevel,
+		PollutionLevel:      record.PollutionLevel,
+		ManufacturYear:      record.ManufacturYear,
+		LastTestDate:        utils.RegistryDate(record.LastTestDate),
+		ValidDate:           utils.RegistryDate(record.ValidDate),
+		Ownership:           record.Ownership,
+		FrameNumber:         record.FrameNumber,
+		Color:               record.Color,
+		FrontWheel:          record.FrontWheel,
+		RearWheel:           record.RearWheel,
+		FuelType:            record.FuelType,
+		FirstOnRoadDate:     utils.RegistryDate(record.FirstOnRoadDate),
+		CommercialName:      record.CommercialName,
+		ManufacturerName:    manufacturerCountryAndName[0],
+		VehicleCategory:     vehicle.VehicleCategoryCar,
+		Status:              vehicle.VehicleStatusActive,
+		ProductionCountry:   record.ProductionCountry,
+		ModelSerialNumber:   record.ModelSerialNumber,
+		EngineSerialNumber:  record.EngineSerialNumber,
+	}
+
+	return vehicleDetails, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+// FetchDisabledPermitByLicensePlate looks the plate up in the disabled parking
+// permit resource. A plate without a permit is not an error.
+func (s *VehicleService) FetchDisabledPermitByLicensePlate(ctx context.Context, licensePlate string) (vehicle.DisabledPermitResponse, error) {
+	plate, err := parseCivilianLicensePlate(licensePlate)
+	if err != nil {
+		return vehicle.DisabledPermitResponse{}, err
+	}
+
+	licenseNumber, err := strconv.Atoi(plate.Canonical)
+	if err != nil {
+		return vehicle.DisabledPermitResponse{}, fmt.Errorf("%w: %v", serrors.ErrInvalidVehicleDetails, err)
+	}
+
+	return s.fetchDisabledPermit(ctx, licenseNumber)
+}
+
+// IncludeDisabledPermit sets the has_disabled_permit flag on the vehicle details.
+// The lookup is bounded so a slow permit resource cannot hold up the vehicle lookup.
+func (s *VehicleService) IncludeDisabledPermit(ctx context.Context, vehicleDetails *vehicle.VehicleResponse) error {
+	ctx, cancel := context.WithTimeout(ctx, config.EnrichmentTimeoutSeconds*time.Second)
+	defer cancel()
+
+	permit, err := s.fetchDisabledPermit(ctx, vehicleDetails.LicenseNumber)
+	if err != nil {
+		return err
+	}
+
+	vehicleDetails.HasDisabledPermit = &permit.HasDisabledPermit
+	return nil
+}
+
+func (s *VehicleService) fetchDisabledPermit(ctx context.Context, licenseNumber int) (vehicle.DisabledPermitResponse, error) {
+	filters := map[string]any{"MISPAR RECHEV": licenseNumber}
+	records, err := searchCKAN[vehicle.DisabledPermitRecord](ctx, s.ckanClient, config.DisabledPermitResourceID, filters, 1)
+	if err != nil {
+		return vehicle.DisabledPermitResponse{}, err
+	}
+
+	permit := vehicle.DisabledPermitResponse{LicenseNumber: licenseNumber}
+	if len(records) == 0 {
+		return permit, nil
+	}
+
+	permit.HasDisabledPermit = true
+	permit.IssueDate = utils.RegistryDate(records[0].IssueDate)
+	if records[0].PermitType != nil {
+		permit.PermitType = fmt.Sprint(records[0].PermitType)
+	}
+
+	return permit, nil
+}
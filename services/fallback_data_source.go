@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+)
+
+// FallbackVehicleDataSource consults its sources in order and answers from the
+// first one that knows the plate. Any error other than ErrNoMatchingVehicle
+// stops the lookup so an upstream outage is not reported as an unknown plate.
+type FallbackVehicleDataSource struct {
+	sources []VehicleDataSource
+}
+
+func NewFallbackVehicleDataSource(sources ...VehicleDataSource) *FallbackVehicleDataSource {
+	return &FallbackVehicleDataSource{sources: sources}
+}
+
+func (s *FallbackVehicleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	for _, source := range s.sources {
+		vehicleDetails, err := source.FetchVehicleDetails(ctx, licensePlate)
+		if err == nil {
+			return vehicleDetails, nil
+		}
+		if !errors.Is(err, serrors.ErrNoMatchingVehicle) {
+			return vehicle.VehicleResponse{}, err
+		}
+	}
+
+	return vehicle.VehicleResponse{}, fmt.Errorf("%w: no matching vehicle for license plate %s", serrors.ErrNoMatchingVehicle, licensePlate)
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+const (
+	barToPsi = 14.5038
+	barToKPa = 100
+)
+
+// TirePressureTableEntry is a curated tire pressure for a make and model,
+// optionally narrowed to a range of model years and a tire size. Pressures may
+// give any of bar, psi or kPa; the missing units are converted from the others.
+type TirePressureTableEntry struct {
+	Make         string                 `json:"make"`
+	Model        string                 `json:"model"`
+	YearFrom     int                    `json:"year_from,omitempty"`
+	YearTo       int                    `json:"year_to,omitempty"`
+	TireSize     string                 `json:"tire_size,omitempty"`
+	Front        *WheelSizeTirePressure `json:"front"`
+	Rear         *WheelSizeTirePressure `json:"rear,omitempty"`
+	FrontMaxLoad *WheelSizeTirePressure `json:"front_max_load,omitempty"`
+	RearMaxLoad  *WheelSizeTirePressure `json:"rear_max_load,omitempty"`
+}
+
+// ReadTirePressureTable loads a JSON array of TirePressureTableEntry from path
+func ReadTirePressureTable(path string) ([]TirePressureTableEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadTirePressureTable, err)
+	}
+
+	var entries []TirePressureTableEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadTirePressureTable, err)
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if strings.TrimSpace(entry.Make) == "" || strings.TrimSpace(entry.Model) == "" || entry.Front == nil {
+			return nil, fmt.Errorf("%w: entry %d needs make, model and front pressure", serrors.ErrLoadTirePressureTable, i)
+		}
+		if entry.TireSize != "" {
+			if _, err := utils.ParseTireSize(entry.TireSize); err != nil {
+				return nil, fmt.Errorf("%w: entry %d: %v", serrors.ErrLoadTirePressureTable, i, err)
+			}
+		}
+		if entry.Rear == nil {
+			entry.Rear = entry.Front
+		}
+		if entry.RearMaxLoad == nil {
+			entry.RearMaxLoad = entry.FrontMaxLoad
+		}
+		for _, pressure := range []*WheelSizeTirePressure{entry.Front, entry.Rear, entry.FrontMaxLoad, entry.RearMaxLoad} {
+			completePressureUnits(pressure)
+		}
+	}
+
+	return entries, nil
+}
+
+// completePressureUnits fills the units of pressure left at zero from the one that is set
+func completePressureUnits(pressure *WheelSizeTirePressure) {
+	if pressure == nil {
+		return
+	}
+
+	bar := pressure.Bar
+	switch {
+	case bar > 0:
+	case pressure.KPa > 0:
+		bar = pressure.KPa / barToKPa
+	case pressure.Psi > 0:
+		bar = pressure.Psi / barToPsi
+	default:
+		return
+	}
+
+	if pressure.Bar == 0 {
+		pressure.Bar = roundPressure(bar, 100)
+	}
+	if pressure.Psi == 0 {
+		pressure.Psi = roundPressure(bar*barToPsi, 1)
+	}
+	if pressure.KPa == 0 {
+		pressure.KPa = roundPressure(bar*barToKPa, 1)
+	}
+}
+
+func roundPressure(value float64, precision float64) float64 {
+	return math.Round(value*precision) / precision
+}
+
+// LocalTirePressureProvider answers tire pressure lookups from a curated local table
+type LocalTirePressureProvider struct {
+	entries []TirePressureTableEntry
+}
+
+func NewLocalTirePressureProvider(entries []TirePressureTableEntry) *LocalTirePressureProvider {
+	return &LocalTirePressureProvider{entries: entries}
+}
+
+func (p *LocalTirePressureProvider) Name() string {
+	return config.TirePressureProviderLocalTable
+}
+
+// FetchTirePressure prefers an entry for the registered front tire size and
+// falls back to an entry that covers the whole model
+func (p *LocalTirePressureProvider) FetchTirePressure(ctx context.Context, vehicleDetails vehicle.VehicleResponse, options TirePressureOptions) (vehicle.TirePressureResponse, error) {
+	englishManufacturer := utils.ConvertManufacturerToEnglish(vehicleDetails.ManufacturerName)
+	if englishManufacturer == "" {
+		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: manufacturer empty or not mapped: %q", serrors.ErrInvalidVehicleDetails, vehicleDetails.ManufacturerName)
+	}
+
+	unit := options.Unit
+	if unit == "" {
+		unit = vehicle.PressureUnitPsi
+	}
+
+	var modelEntry *TirePressureTableEntry
+	for i := range p.entries {
+		entry := &p.entries[i]
+		if !entry.covers(englishManufacturer, vehicleDetails.CommercialName, vehicleDetails.ManufacturYear) {
+			continue
+		}
+
+		if entry.TireSize == "" {
+			if modelEntry == nil {
+				modelEntry = entry
+			}
+			continue
+		}
+		if vehicleDetails.FrontWheelSpec != nil && tireMatches(entry.TireSize, vehicleDetails.FrontWheelSpec) {
+			tirePressureResponse := entry.response(unit, vehicle.TirePressureMatchExact)
+			tirePressureResponse.Note = fmt.Sprintf("Read from the curated %s %s entry for tire size %s.", entry.Make, entry.Model, entry.TireSize)
+			if options.Debug {
+				tirePressureResponse.Raw = *entry
+			}
+			return tirePressureResponse, nil
+		}
+	}
+
+	if modelEntry != nil {
+		tirePressureResponse := modelEntry.response(unit, vehicle.TirePressureMatchModel)
+		tirePressureResponse.Note = fmt.Sprintf("Read from the curated %s %s entry covering every tire size of the model.", modelEntry.Make, modelEntry.Model)
+		if options.Debug {
+			tirePressureResponse.Raw = *modelEntry
+		}
+		return tirePressureResponse, nil
+	}
+
+	return vehicle.TirePressureResponse{}, fmt.Errorf("%w: no local table entry for %s %s %d", serrors.ErrNoTirePressureData, englishManufacturer, vehicleDetails.CommercialName, vehicleDetails.ManufacturYear)
+}
+
+// covers reports whether the entry applies to the make, registry commercial name and model year
+func (e *TirePressureTableEntry) covers(manufacturer string, commercialName string, year int) bool {
+	if normalizeCatalogName(e.Make) != normalizeCatalogName(manufacturer) {
+		return false
+	}
+	if (e.YearFrom > 0 && year < e.YearFrom) || (e.YearTo > 0 && year > e.YearTo) {
+		return false
+	}
+
+	model := normalizeCatalogName(e.Model)
+	if model == normalizeCatalogName(commercialName) {
+		return true
+	}
+	trimmed, found := trimCatalogPrefix(strings.TrimSpace(commercialName), manufacturer)
+	return found && model == normalizeCatalogName(trimmed)
+}
+
+func (e *TirePressureTableEntry) response(unit string, match string) vehicle.TirePressureResponse {
+	front := WheelSizeTireData{Tire: e.TireSize, TirePressure: e.Front, TirePressureMaxLoad: e.FrontMaxLoad}
+	rear := WheelSizeTireData{Tire: e.TireSize, TirePressure: e.Rear, TirePressureMaxLoad: e.RearMaxLoad}
+	return newTirePressureResponse(front, rear, unit, match)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+)
+
+// IncludeModelSpecs joins the vehicle's model against the model specification
+// resource and attaches the matching specs. Vehicles whose model has no
+// specification row are left without specs. The lookup is bounded so a slow
+// specification resource cannot hold up the vehicle lookup.
+func (s *VehicleService) IncludeModelSpecs(ctx context.Context, vehicleDetails *vehicle.VehicleResponse) error {
+	if vehicleDetails.ProductionCountry == 0 || vehicleDetails.ModelSerialNumber == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.EnrichmentTimeoutSeconds*time.Second)
+	defer cancel()
+
+	filters := map[string]any{
+		"tozeret_cd":   vehicleDetails.ProductionCountry,
+		"degem_cd":     vehicleDetails.ModelSerialNumber,
+		"shnat_yitzur": vehicleDetails.ManufacturYear,
+	}
+	records, err := searchCKAN[vehicle.ModelSpecsRecord](ctx, s.ckanClient, config.ModelSpecsResourceID, filters, config.CKANMaxRecords)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	// A model code can cover several engines; prefer the row for the vehicle's own engine
+	record := records[0]
+	for _, candidate := range records {
+		if strings.EqualFold(strings.TrimSpace(candidate.EngineSerialNumber), strings.TrimSpace(vehicleDetails.EngineSerialNumber)) {
+			record = candidate
+			break
+		}
+	}
+
+	vehicleDetails.Specs = &vehicle.ModelSpecs{
+		EngineVolume:            record.EngineVolume,
+		Horsepower:              record.Horsepower,
+		FuelConsumptionUrban:    record.FuelConsumptionUrban,
+		FuelConsumptionExtra:    record.FuelConsumptionExtra,
+		FuelConsumptionCombined: record.FuelConsumptionCombined,
+		CO2:                     record.CO2,
+		Seats:                   record.Seats,
+		DriveType:               record.DriveType,
+		TotalWeight:             record.TotalWeight,
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+func (s *VehicleService) FetchOwnershipHistoryByLicensePlate(ctx context.Context, licensePlate string) (vehicle.OwnershipHistoryResponse, error) {
+	plate, err := parseCivilianLicensePlate(licensePlate)
+	if err != nil {
+		return vehicle.OwnershipHistoryResponse{}, err
+	}
+
+	licenseNumber, err := strconv.Atoi(plate.Canonical)
+	if err != nil {
+		return vehicle.OwnershipHistoryResponse{}, fmt.Errorf("%w: %v", serrors.ErrInvalidVehicleDetails, err)
+	}
+
+	filters := map[string]any{"mispar_rechev": licenseNumber}
+	records, err := searchCKAN[vehicle.OwnershipHistoryRecord](ctx, s.ckanClient, config.OwnershipHistoryResourceID, filters, config.CKANMaxRecords)
+	if err != nil {
+		return vehicle.OwnershipHistoryResponse{}, err
+	}
+
+	if len(records) == 0 {
+		return vehicle.OwnershipHistoryResponse{}, fmt.Errorf("%w: no ownership history for license plate %s", serrors.ErrNoMatchingVehicle, licensePlate)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].OwnershipDate < records[j].OwnershipDate
+	})
+
+	periods := make([]vehicle.OwnershipPeriod, 0, len(records))
+	for i, record := range records {
+		period := vehicle.OwnershipPeriod{
+			Ownership:     record.Ownership,
+			OwnershipType: utils.ConvertOwnershipToEnglish(record.Ownership),
+			StartDate:     utils.RegistryDate(strconv.Itoa(record.OwnershipDate)),
+		}
+		if i+1 < len(records) {
+			period.EndDate = utils.RegistryDate(strconv.Itoa(records[i+1].OwnershipDate))
+		}
+		periods = append(periods, period)
+	}
+
+	return vehicle.OwnershipHistoryResponse{LicenseNumber: licenseNumber, Periods: periods}, nil
+}
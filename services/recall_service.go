@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	"car-license-number-fetcher/utils"
+)
+
+// FetchOpenRecallsByLicensePlate joins the vehicle's model against the recall
+// campaigns resource and returns the campaigns still open for its production year
+func (s *VehicleService) FetchOpenRecallsByLicensePlate(ctx context.Context, licensePlate string) (vehicle.RecallsResponse, error) {
+	vehicleDetails, err := s.FetchVehicleDetailsByLicensePlate(ctx, licensePlate)
+	if err != nil {
+		return vehicle.RecallsResponse{}, err
+	}
+
+	recallsResponse := vehicle.RecallsResponse{
+		LicenseNumber:     vehicleDetails.LicenseNumber,
+		ProductionCountry: vehicleDetails.ProductionCountry,
+		ModelSerialNumber: vehicleDetails.ModelSerialNumber,
+		ManufacturYear:    vehicleDetails.ManufacturYear,
+		Recalls:           []vehicle.Recall{},
+	}
+
+	if vehicleDetails.ProductionCountry == 0 || vehicleDetails.ModelSerialNumber == 0 {
+		return recallsResponse, nil
+	}
+
+	filters := map[string]any{
+		"tozeret_cd": vehicleDetails.ProductionCountry,
+		"degem_cd":   vehicleDetails.ModelSerialNumber,
+	}
+	records, err := searchCKAN[vehicle.RecallRecord](ctx, s.ckanClient, config.RecallResourceID, filters, config.CKANMaxRecords)
+	if err != nil {
+		return vehicle.RecallsResponse{}, err
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record.CloseDate) != "" {
+			continue
+		}
+		if !recallCoversYear(record, vehicleDetails.ManufacturYear) {
+			continue
+		}
+		recallsResponse.Recalls = append(recallsResponse.Recalls, vehicle.Recall{
+			RecallID:    record.RecallID,
+			Description: record.Description,
+			Remedy:      record.Remedy,
+			OpenDate:    utils.RegistryDate(record.OpenDate),
+		})
+	}
+
+	return recallsResponse, nil
+}
+
+// recallCoversYear reports whether a campaign applies to the production year;
+// a missing bound leaves that side of the range open
+func recallCoversYear(record vehicle.RecallRecord, year int) bool {
+	if record.FromYear > 0 && year < record.FromYear {
+		return false
+	}
+	if record.ToYear > 0 && year > record.ToYear {
+		return false
+	}
+	return true
+}
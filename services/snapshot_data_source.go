@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	snapshotMetaBucket     = []byte("meta")
+	snapshotVehiclesBucket = []byte("vehicles")
+
+	snapshotDateKey   = []byte("snapshot_date")
+	snapshotSourceKey = []byte("resource_id")
+	snapshotCountKey  = []byte("record_count")
+)
+
+// snapshotWriteBatchSize is how many records are written per transaction while importing
+const snapshotWriteBatchSize = 10000
+
+// snapshotKey encodes a license number as the big-endian key records are stored under
+func snapshotKey(licenseNumber int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(licenseNumber))
+	return key
+}
+
+// VehicleSnapshotWriter builds a snapshot store: an embedded bbolt database of
+// VehicleRecords keyed by mispar_rechev. Records are written in batches so an
+// import never holds more than one batch in memory.
+type VehicleSnapshotWriter struct {
+	db        *bolt.DB
+	tx        *bolt.Tx
+	path      string
+	tmpPath   string
+	pending   int
+	count     int
+	duplicate int
+}
+
+// CreateVehicleSnapshot starts a new snapshot that replaces path once Close succeeds
+func CreateVehicleSnapshot(path string) (*VehicleSnapshotWriter, error) {
+	tmpPath := path + ".tmp"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	db, err := bolt.Open(tmpPath, 0o644, &bolt.Options{Timeout: time.Second, NoSync: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	writer := &VehicleSnapshotWriter{db: db, path: path, tmpPath: tmpPath}
+	if err := writer.begin(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (w *VehicleSnapshotWriter) begin() error {
+	tx, err := w.db.Begin(true)
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+	if _, err := tx.CreateBucketIfNotExists(snapshotVehiclesBucket); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	w.tx = tx
+	w.pending = 0
+	return nil
+}
+
+// Add stores a record. A later record for the same plate replaces the earlier one.
+func (w *VehicleSnapshotWriter) Add(record vehicle.VehicleRecord) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	vehicles := w.tx.Bucket(snapshotVehiclesBucket)
+	key := snapshotKey(record.LicenseNumber)
+	if vehicles.Get(key) != nil {
+		w.duplicate++
+	} else {
+		w.count++
+	}
+	if err := vehicles.Put(key, encoded); err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	w.pending++
+	if w.pending < snapshotWriteBatchSize {
+		return nil
+	}
+	if err := w.tx.Commit(); err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+	return w.begin()
+}
+
+// Count returns the number of distinct plates added so far and how many rows repeated an earlier plate
+func (w *VehicleSnapshotWriter) Count() (int, int) {
+	return w.count, w.duplicate
+}
+
+// Close records the snapshot date and source, flushes the store to disk and moves it into place
+func (w *VehicleSnapshotWriter) Close(snapshotDate string, resourceID string) error {
+	meta, err := w.tx.CreateBucketIfNotExists(snapshotMetaBucket)
+	if err == nil {
+		err = meta.Put(snapshotDateKey, []byte(snapshotDate))
+	}
+	if err == nil {
+		err = meta.Put(snapshotSourceKey, []byte(resourceID))
+	}
+	if err == nil {
+		err = meta.Put(snapshotCountKey, []byte(strconv.Itoa(w.count)))
+	}
+	if err == nil {
+		err = w.tx.Commit()
+	} else {
+		w.tx.Rollback()
+	}
+	if err == nil {
+		err = w.db.Sync()
+	}
+	if closeErr := w.db.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(w.tmpPath, w.path)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	return nil
+}
+
+// Abort discards a snapshot that failed to import
+func (w *VehicleSnapshotWriter) Abort() {
+	w.tx.Rollback()
+	w.db.Close()
+	os.Remove(w.tmpPath)
+}
+
+// VehicleSnapshot is a read-only handle on a snapshot store written by VehicleSnapshotWriter
+type VehicleSnapshot struct {
+	db           *bolt.DB
+	SnapshotDate string
+	ResourceID   string
+	RecordCount  int
+}
+
+// OpenVehicleSnapshot opens the snapshot store at path for lookups
+func OpenVehicleSnapshot(path string) (*VehicleSnapshot, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	db, err := bolt.Open(path, 0o444, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	snapshot := &VehicleSnapshot{db: db}
+	err = db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(snapshotVehiclesBucket) == nil {
+			return fmt.Errorf("no vehicles in %s", path)
+		}
+		meta := tx.Bucket(snapshotMetaBucket)
+		if meta == nil {
+			return fmt.Errorf("no snapshot metadata in %s", path)
+		}
+		snapshot.SnapshotDate = string(meta.Get(snapshotDateKey))
+		snapshot.ResourceID = string(meta.Get(snapshotSourceKey))
+		snapshot.RecordCount, _ = strconv.Atoi(string(meta.Get(snapshotCountKey)))
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	return snapshot, nil
+}
+
+// Lookup returns the record stored for licenseNumber
+func (s *VehicleSnapshot) Lookup(licenseNumber int) (vehicle.VehicleRecord, bool, error) {
+	var record vehicle.VehicleRecord
+	found := false
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		encoded := tx.Bucket(snapshotVehiclesBucket).Get(snapshotKey(licenseNumber))
+		if encoded == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(encoded, &record)
+	})
+	if err != nil {
+		return vehicle.VehicleRecord{}, false, fmt.Errorf("%w: %v", serrors.ErrParseResponse, err)
+	}
+
+	return record, found, nil
+}
+
+func (s *VehicleSnapshot) Close() error {
+	return s.db.Close()
+}
+
+// SnapshotVehicleDataSource answers vehicle lookups from a local registry snapshot
+type SnapshotVehicleDataSource struct {
+	snapshot *VehicleSnapshot
+}
+
+func NewSnapshotVehicleDataSource(snapshot *VehicleSnapshot) *SnapshotVehicleDataSource {
+	return &SnapshotVehicleDataSource{snapshot: snapshot}
+}
+
+func (s *SnapshotVehicleDataSource) FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	licenseNumber, err := strconv.Atoi(licensePlate)
+	if err != nil {
+		return vehicle.VehicleResponse{}, fmt.Errorf("%w: no matching vehicle for license plate %s", serrors.ErrNoMatchingVehicle, licensePlate)
+	}
+
+	record, found, err := s.snapshot.Lookup(licenseNumber)
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+	if !found {
+		return vehicle.VehicleResponse{}, fmt.Errorf("%w: no matching vehicle for license plate %s", serrors.ErrNoMatchingVehicle, licensePlate)
+	}
+
+	vehicleDetails, err := buildVehicleResponse(record)
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
+	}
+	vehicleDetails.SnapshotDate = s.snapshot.SnapshotDate
+
+	return vehicleDetails, nil
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+)
+
+const (
+	SnapshotFormatCSV  = "csv"
+	SnapshotFormatJSON = "json"
+)
+
+// ckanField is a column definition of a CKAN datastore dump
+type ckanField struct {
+	ID string `json:"id"`
+}
+
+// ReadVehicleRecords streams a CKAN export of the vehicle registry resource in
+// the given format, calling emit with each row as a VehicleRecord
+func ReadVehicleRecords(r io.Reader, format string, emit func(vehicle.VehicleRecord) error) error {
+	switch strings.ToLower(format) {
+	case SnapshotFormatCSV:
+		return readVehicleRecordsCSV(r, emit)
+	case SnapshotFormatJSON:
+		return readVehicleRecordsJSON(r, emit)
+	default:
+		return fmt.Errorf("%w: unsupported snapshot format %q", serrors.ErrLoadSnapshot, format)
+	}
+}
+
+func readVehicleRecordsCSV(r io.Reader, emit func(vehicle.VehicleRecord) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%w: reading csv header: %v", serrors.ErrLoadSnapshot, err)
+	}
+	header = append([]string(nil), header...)
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+	}
+
+	columns := make(map[string]string, len(header))
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: reading csv line %d: %v", serrors.ErrLoadSnapshot, line, err)
+		}
+
+		clear(columns)
+		for i, value := range row {
+			if i < len(header) {
+				columns[header[i]] = value
+			}
+		}
+
+		record, err := vehicleRecordFromColumns(columns)
+		if err != nil {
+			return fmt.Errorf("%w: csv line %d: %v", serrors.ErrLoadSnapshot, line, err)
+		}
+		if err := emit(record); err != nil {
+			return err
+		}
+	}
+}
+
+// readVehicleRecordsJSON walks the JSON shapes CKAN exports a datastore
+// resource in without loading the whole export: the datastore dump
+// ({"fields": [...], "records": [[...]]}), a datastore_search response
+// ({"result": {"records": [{...}]}}) or a bare array of records
+func readVehicleRecordsJSON(r io.Reader, emit func(vehicle.VehicleRecord) error) error {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	records := jsonRecordStream{decoder: decoder, emit: emit}
+	switch token {
+	case json.Delim('['):
+		err = records.readArray()
+	case json.Delim('{'):
+		err = records.readObject()
+	default:
+		err = fmt.Errorf("unexpected %v at start of export", token)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", serrors.ErrLoadSnapshot, err)
+	}
+
+	return nil
+}
+
+type jsonRecordStream struct {
+	decoder *json.Decoder
+	emit    func(vehicle.VehicleRecord) error
+	fields  []ckanField
+	count   int
+}
+
+// readObject reads the members of an object whose opening brace was consumed,
+// descending into "result" and streaming "records"
+func (s *jsonRecordStream) readObject() error {
+	for s.decoder.More() {
+		keyToken, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch keyToken {
+		case "fields":
+			if err := s.decoder.Decode(&s.fields); err != nil {
+				return err
+			}
+		case "records":
+			if err := s.expectDelim('['); err != nil {
+				return err
+			}
+			if err := s.readArray(); err != nil {
+				return err
+			}
+		case "result":
+			if err := s.expectDelim('{'); err != nil {
+				return err
+			}
+			if err := s.readObject(); err != nil {
+				return err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := s.decoder.Decode(&skipped); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := s.decoder.Token()
+	return err
+}
+
+// readArray emits each record of an array whose opening bracket was consumed
+func (s *jsonRecordStream) readArray() error {
+	for s.decoder.More() {
+		var rawRecord json.RawMessage
+		if err := s.decoder.Decode(&rawRecord); err != nil {
+			return fmt.Errorf("record %d: %v", s.count, err)
+		}
+
+		columns, err := jsonRecordColumns(rawRecord, s.fields)
+		if err != nil {
+			return fmt.Errorf("record %d: %v", s.count, err)
+		}
+
+		record, err := vehicleRecordFromColumns(columns)
+		if err != nil {
+			return fmt.Errorf("record %d: %v", s.count, err)
+		}
+		if err := s.emit(record); err != nil {
+			return err
+		}
+		s.count++
+	}
+
+	_, err := s.decoder.Token()
+	return err
+}
+
+func (s *jsonRecordStream) expectDelim(delim json.Delim) error {
+	token, err := s.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, found %v", delim, token)
+	}
+	return nil
+}
+
+// jsonRecordColumns flattens a JSON record, either an object or a row array
+// ordered like fields, into column name to raw text value
+func jsonRecordColumns(rawRecord json.RawMessage, fields []ckanField) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawRecord))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	columns := map[string]string{}
+	switch v := value.(type) {
+	case map[string]any:
+		for column, columnValue := range v {
+			columns[column] = jsonValueText(columnValue)
+		}
+	case []any:
+		if len(fields) == 0 {
+			return nil, errors.New("row array without field definitions")
+		}
+		for i, columnValue := range v {
+			if i < len(fields) {
+				columns[fields[i].ID] = jsonValueText(columnValue)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected record type %T", value)
+	}
+
+	return columns, nil
+}
+
+func jsonValueText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// vehicleRecordFromColumns converts textual column values into a VehicleRecord,
+// typing each value after the VehicleRecord field sharing its json tag so the
+// live and snapshot paths decode the same columns the same way
+func vehicleRecordFromColumns(columns map[string]string) (vehicle.VehicleRecord, error) {
+	recordType := reflect.TypeOf(vehicle.VehicleRecord{})
+	typed := make(map[string]any, len(columns))
+
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		column := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		value, found := columns[column]
+		value = strings.TrimSpace(value)
+		if !found || value == "" {
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Int:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return vehicle.VehicleRecord{}, fmt.Errorf("column %s: %v", column, err)
+			}
+			typed[column] = int(number)
+		case reflect.Float64:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return vehicle.VehicleRecord{}, fmt.Errorf("column %s: %v", column, err)
+			}
+			typed[column] = number
+		default:
+			typed[column] = value
+		}
+	}
+
+	encoded, err := json.Marshal(typed)
+	if err != nil {
+		return vehicle.VehicleRecord{}, err
+	}
+
+	var record vehicle.VehicleRecord
+	if err := json.Unmarshal(encoded, &record); err != nil {
+		return vehicle.VehicleRecord{}, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+)
+
+// TirePressureProvider looks up the recommended tire pressures of a vehicle
+type TirePressureProvider interface {
+	// Name identifies the provider in configuration and in TirePressureResponse.Source
+	Name() string
+	FetchTirePressure(ctx context.Context, vehicleDetails vehicle.VehicleResponse, options TirePressureOptions) (vehicle.TirePressureResponse, error)
+}
+
+// TirePressureService asks its providers in order and answers with the first
+// one that has pressures for the vehicle
+type TirePressureService struct {
+	providers []TirePressureProvider
+}
+
+func NewTirePressureService(providers ...TirePressureProvider) *TirePressureService {
+	return &TirePressureService{providers: providers}
+}
+
+func (s *TirePressureService) FetchTirePressure(ctx context.Context, vehicleDetails vehicle.VehicleResponse, options TirePressureOptions) (vehicle.TirePressureResponse, error) {
+	if len(s.providers) == 0 {
+		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: no tire pressure providers configured", serrors.ErrNoTirePressureData)
+	}
+
+	var providerErrors []error
+	var failedProviders []string
+	for _, provider := range s.providers {
+		tirePressureResponse, err := provider.FetchTirePressure(ctx, vehicleDetails, options)
+		if err == nil {
+			tirePressureResponse.Source = provider.Name()
+			if len(providerErrors) > 0 {
+				tirePressureResponse.Note += fmt.Sprintf(" %s had no answer, so %s was used.", strings.Join(failedProviders, " and "), provider.Name())
+			}
+			return tirePressureResponse, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return vehicle.TirePressureResponse{}, ctxErr
+		}
+
+		log.Printf("Tire pressure provider %s failed for license plate %d: %s", provider.Name(), vehicleDetails.LicenseNumber, err)
+		providerErrors = append(providerErrors, fmt.Errorf("%s: %w", provider.Name(), err))
+		failedProviders = append(failedProviders, provider.Name())
+	}
+
+	return vehicle.TirePressureResponse{}, errors.Join(providerErrors...)
+}
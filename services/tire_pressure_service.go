@@ -1,12 +1,9 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"os"
 	"strings"
 
 	config "car-license-number-fetcher/config"
@@ -19,28 +16,104 @@ import (
 type WheelSizeAPIResponse struct {
 	Data []WheelSizeVehicleData `json:"data"`
 	Meta struct {
-		Count   int `json:"count"`
+		Count   int            `json:"count"`
 		Regions map[string]int `json:"regions"`
 	} `json:"meta"`
 }
 
 // WheelSizeVehicleData represents a vehicle data entry in the API response
 type WheelSizeVehicleData struct {
-	Slug    string `json:"slug"`
-	Name    string `json:"name"`
-	Wheels  []WheelSizeWheel `json:"wheels"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	Technical WheelSizeTechnical `json:"technical"`
+	Wheels    []WheelSizeWheel   `json:"wheels"`
+}
+
+// WheelSizeTechnical represents the hub specifications shared by all wheels of a model
+type WheelSizeTechnical struct {
+	BoltPattern string `json:"bolt_pattern"`
+	CenterBore  any    `json:"centre_bore"`
 }
 
 // WheelSizeWheel represents a wheel configuration
 type WheelSizeWheel struct {
-	IsStock bool `json:"is_stock"`
+	IsStock bool              `json:"is_stock"`
 	Front   WheelSizeTireData `json:"front"`
 	Rear    WheelSizeTireData `json:"rear"`
 }
 
 // WheelSizeTireData represents tire data for front or rear
 type WheelSizeTireData struct {
-	TirePressure *WheelSizeTirePressure `json:"tire_pressure"`
+	Tire                string                 `json:"tire"`
+	TireFull            string                 `json:"tire_full"`
+	Rim                 string                 `json:"rim"`
+	RimDiameter         float64                `json:"rim_diameter"`
+	RimWidth            float64                `json:"rim_width"`
+	RimOffset           float64                `json:"rim_offset"`
+	TirePressure        *WheelSizeTirePressure `json:"tire_pressure"`
+	TirePressureMaxLoad *WheelSizeTirePressure `json:"tire_pressure_max_load"`
+}
+
+// UnmarshalJSON reads tire_pressure either as a single pressure for normal
+// load or as an object holding separate normal and max_load pressures. Units a
+// pressure is not given in are converted from the ones it is.
+func (d *WheelSizeTireData) UnmarshalJSON(data []byte) error {
+	type wheelSizeTireData WheelSizeTireData
+	var aux struct {
+		wheelSizeTireData
+		TirePressure json.RawMessage `json:"tire_pressure"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*d = WheelSizeTireData(aux.wheelSizeTireData)
+
+	if len(aux.TirePressure) > 0 && string(aux.TirePressure) != "null" {
+		if err := d.unmarshalTirePressure(aux.TirePressure); err != nil {
+			return err
+		}
+	}
+
+	d.TirePressure = completedPressure(d.TirePressure)
+	d.TirePressureMaxLoad = completedPressure(d.TirePressureMaxLoad)
+	return nil
+}
+
+func (d *WheelSizeTireData) unmarshalTirePressure(data json.RawMessage) error {
+	var byLoad struct {
+		Normal   *WheelSizeTirePressure `json:"normal"`
+		MaxLoad  *WheelSizeTirePressure `json:"max_load"`
+		FullLoad *WheelSizeTirePressure `json:"full_load"`
+	}
+	if err := json.Unmarshal(data, &byLoad); err != nil {
+		return err
+	}
+	if byLoad.Normal != nil || byLoad.MaxLoad != nil || byLoad.FullLoad != nil {
+		d.TirePressure = byLoad.Normal
+		if byLoad.MaxLoad != nil {
+			d.TirePressureMaxLoad = byLoad.MaxLoad
+		} else if byLoad.FullLoad != nil {
+			d.TirePressureMaxLoad = byLoad.FullLoad
+		}
+		return nil
+	}
+
+	var pressure WheelSizeTirePressure
+	if err := json.Unmarshal(data, &pressure); err != nil {
+		return err
+	}
+	d.TirePressure = &pressure
+	return nil
+}
+
+// completedPressure fills in the missing units of pressure, dropping a
+// pressure given in no unit at all rather than reporting it as zero
+func completedPressure(pressure *WheelSizeTirePressure) *WheelSizeTirePressure {
+	completePressureUnits(pressure)
+	if pressure == nil || pressure.Bar == 0 {
+		return nil
+	}
+	return pressure
 }
 
 // WheelSizeTirePressure represents tire pressure values
@@ -50,116 +123,205 @@ type WheelSizeTirePressure struct {
 	KPa float64 `json:"kPa"`
 }
 
-func FetchTirePressureByVehicleDetails(vehicleDetails vehicle.VehicleResponse) (vehicle.TirePressureResponse, error) {
-	apiKey := os.Getenv(config.WheelSizeAPIKeyEnvVar)
-	if apiKey == "" {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: %s environment variable is not set", serrors.ErrInvalidVehicleDetails, config.WheelSizeAPIKeyEnvVar)
+// TirePressureOptions selects how a tire pressure lookup is reported
+type TirePressureOptions struct {
+	Unit string
+	// Region forces a wheel-size market; empty infers it from the manufacturer country
+	Region string
+	// Debug returns the provider's raw payload in TirePressureResponse.Raw
+	Debug bool
+}
+
+// ParseTirePressureUnit validates the unit query parameter, defaulting to psi
+func ParseTirePressureUnit(unit string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", strings.ToLower(vehicle.PressureUnitPsi):
+		return vehicle.PressureUnitPsi, nil
+	case strings.ToLower(vehicle.PressureUnitBar):
+		return vehicle.PressureUnitBar, nil
+	case strings.ToLower(vehicle.PressureUnitKPa):
+		return vehicle.PressureUnitKPa, nil
+	case strings.ToLower(vehicle.PressureUnitAll):
+		return vehicle.PressureUnitAll, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported pressure unit %q, expected psi, bar, kPa or all", serrors.ErrInvalidVehicleDetails, unit)
 	}
-	commercial := strings.TrimSpace(vehicleDetails.CommercialName)
-	if commercial == "" {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: commercial name (model) is empty", serrors.ErrInvalidVehicleDetails)
+}
+
+// ParseWheelSizeRegion validates the region query parameter. An empty region
+// is returned as is and means the market is inferred per vehicle.
+func ParseWheelSizeRegion(region string) (string, error) {
+	region = strings.ToLower(strings.TrimSpace(region))
+	if len(region) > 10 {
+		return "", fmt.Errorf("%w: unsupported wheel-size region %q", serrors.ErrInvalidVehicleDetails, region)
 	}
-	if vehicleDetails.ManufacturYear <= 0 {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: invalid manufacture year: %d", serrors.ErrInvalidVehicleDetails, vehicleDetails.ManufacturYear)
+	for _, r := range region {
+		if r < 'a' || r > 'z' {
+			return "", fmt.Errorf("%w: unsupported wheel-size region %q", serrors.ErrInvalidVehicleDetails, region)
+		}
 	}
 
+	return region, nil
+}
 
-	baseURL, err := url.Parse(config.WheelSizeAPIEndpoint)
-	if err != nil {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: error parsing wheel-size API endpoint: %v", serrors.ErrFetchTirePressure, err)
-	}
+// WheelSizeTirePressureProvider reads tire pressures from the wheel-size.com API
+type WheelSizeTirePressureProvider struct {
+	wheelSize *WheelSizeClient
+}
 
-	englishManufacturer := utils.ConvertManufacturerToEnglish(vehicleDetails.ManufacturerName)
+func NewWheelSizeTirePressureProvider(wheelSize *WheelSizeClient) *WheelSizeTirePressureProvider {
+	return &WheelSizeTirePressureProvider{wheelSize: wheelSize}
+}
+
+func (p *WheelSizeTirePressureProvider) Name() string {
+	return config.TirePressureProviderWheelSize
+}
 
-	if englishManufacturer == "" {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: manufacturer empty or not mapped: %q", serrors.ErrInvalidVehicleDetails, vehicleDetails.ManufacturerName)
+func (p *WheelSizeTirePressureProvider) FetchTirePressure(ctx context.Context, vehicleDetails vehicle.VehicleResponse, options TirePressureOptions) (vehicle.TirePressureResponse, error) {
+	lookup, err := p.wheelSize.fetchVehicleData(ctx, vehicleDetails, options.Region)
+	if err != nil {
+		return vehicle.TirePressureResponse{}, err
 	}
-	
-	params := url.Values{}
-	params.Add("make", englishManufacturer)
-	params.Add("model", commercial)
-	params.Add("year", fmt.Sprintf("%d", vehicleDetails.ManufacturYear))
-	params.Add("region", config.WheelSizeDefaultRegion)
-	params.Add("user_key", apiKey)
 
+	unit := options.Unit
+	if unit == "" {
+		unit = vehicle.PressureUnitPsi
+	}
 
-	baseURL.RawQuery = params.Encode()
+	wheel, match, found := selectWheel(lookup.Vehicle.Wheels, vehicleDetails.FrontWheelSpec, vehicleDetails.RearWheelSpec)
+	if !found {
+		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: no tire pressure values present", serrors.ErrNoTirePressureData)
+	}
 
-	req, err := http.NewRequest("GET", baseURL.String(), nil)
-	if err != nil {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: error creating request: %v", serrors.ErrFetchTirePressure, err)
+	tirePressureResponse := newTirePressureResponse(wheel.Front, wheel.Rear, unit, match)
+	tirePressureResponse.Region = lookup.Region
+	tirePressureResponse.Resolution = &lookup.Resolution
+	tirePressureResponse.Note = wheelSizeNote(wheel, match, lookup)
+	if options.Debug {
+		tirePressureResponse.Raw = lookup.Raw
 	}
 
-	req.Header.Set("accept", "application/json")
+	return tirePressureResponse, nil
+}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: %v", serrors.ErrFetchTirePressure, err)
+// wheelSizeNote explains which wheel configuration and market a wheel-size pressure was read from
+func wheelSizeNote(wheel WheelSizeWheel, match string, lookup wheelSizeLookup) string {
+	var note string
+	switch match {
+	case vehicle.TirePressureMatchExact:
+		note = fmt.Sprintf("Read from the %s wheel fitted with the registered tire size %s.", lookup.Vehicle.Name, wheel.Front.Tire)
+	case vehicle.TirePressureMatchStock:
+		note = fmt.Sprintf("The registered tire size is not listed for the %s; read from its stock wheel with %s tires.", lookup.Vehicle.Name, wheel.Front.Tire)
+	default:
+		note = fmt.Sprintf("The %s lists no wheel with the registered tire size and no stock wheel with pressures; read from its first wheel with pressures, fitted with %s tires.", lookup.Vehicle.Name, wheel.Front.Tire)
 	}
-	defer res.Body.Close()
 
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: error reading response body: %v", serrors.ErrParseResponse, err)
+	if lookup.Region != lookup.PreferredRegion {
+		note += fmt.Sprintf(" wheel-size has no data for this model in region %s, so region %s was used.", lookup.PreferredRegion, lookup.Region)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: status %d", serrors.ErrResponseNotSuccessful, res.StatusCode)
+	return note
+}
+
+// newTirePressureResponse reports the normal and max-load pressures of the
+// front and rear tires in unit, along with the legacy psi fields
+func newTirePressureResponse(front WheelSizeTireData, rear WheelSizeTireData, unit string, match string) vehicle.TirePressureResponse {
+	if strings.TrimSpace(rear.Tire) == "" && rear.TirePressure == nil {
+		// wheel-size leaves the rear empty when both axles share the front fitment
+		rear = front
 	}
 
-	var wheelSizeResponse WheelSizeAPIResponse
-	if err := json.Unmarshal(resBody, &wheelSizeResponse); err != nil {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: %v", serrors.ErrParseResponse, err)
+	tirePressureResponse := vehicle.TirePressureResponse{
+		Front:        axlePressure(front.TirePressure, unit),
+		Rear:         axlePressure(rear.TirePressure, unit),
+		FrontMaxLoad: axlePressure(front.TirePressureMaxLoad, unit),
+		RearMaxLoad:  axlePressure(rear.TirePressureMaxLoad, unit),
+		Unit:         unit,
+		Match:        match,
+	}
+	if front.TirePressure != nil {
+		psi := front.TirePressure.Psi
+		tirePressureResponse.FrontPsi = &psi
+	}
+	if rear.TirePressure != nil {
+		psi := rear.TirePressure.Psi
+		tirePressureResponse.RearPsi = &psi
 	}
 
-	if len(wheelSizeResponse.Data) == 0 {
-		return vehicle.TirePressureResponse{}, fmt.Errorf("%w: no vehicle data found", serrors.ErrNoTirePressureData)
+	return tirePressureResponse
+}
 
+// axlePressure reports a wheel-size pressure in the requested unit, or in every unit for PressureUnitAll
+func axlePressure(pressure *WheelSizeTirePressure, unit string) *vehicle.AxlePressure {
+	if pressure == nil {
+		return nil
 	}
 
+	bar, psi, kPa := pressure.Bar, pressure.Psi, pressure.KPa
+	switch unit {
+	case vehicle.PressureUnitBar:
+		return &vehicle.AxlePressure{Bar: &bar}
+	case vehicle.PressureUnitKPa:
+		return &vehicle.AxlePressure{KPa: &kPa}
+	case vehicle.PressureUnitAll:
+		return &vehicle.AxlePressure{Psi: &psi, Bar: &bar, KPa: &kPa}
+	default:
+		return &vehicle.AxlePressure{Psi: &psi}
+	}
+}
 
-	vehicleData := wheelSizeResponse.Data[0]
-	var frontPsi, rearPsi *float64
-	var foundStockWheel bool
-
-	for _, wheel := range vehicleData.Wheels {
-		if wheel.IsStock {
-			if wheel.Front.TirePressure != nil {
-				psi := wheel.Front.TirePressure.Psi
-				frontPsi = &psi
+// selectWheel picks the wheel configuration to read pressures from: the one
+// fitted with the registered front and rear tire sizes, else the first stock
+// wheel, else the first entry. Wheels without pressures are skipped. It reports
+// which of these it settled on.
+func selectWheel(wheels []WheelSizeWheel, frontTire *vehicle.TireSize, rearTire *vehicle.TireSize) (WheelSizeWheel, string, bool) {
+	if frontTire != nil {
+		if rearTire == nil {
+			rearTire = frontTire
+		}
+		for _, wheel := range wheels {
+			if !wheel.hasTirePressure() {
+				continue
+			}
+			rear := wheel.Rear
+			if strings.TrimSpace(rear.Tire) == "" {
+				// wheel-size leaves the rear empty when both axles share the front fitment
+				rear = wheel.Front
 			}
-			if wheel.Rear.TirePressure != nil {
-				psi := wheel.Rear.TirePressure.Psi
-				rearPsi = &psi
+			if tireMatches(wheel.Front.Tire, frontTire) && tireMatches(rear.Tire, rearTire) {
+				return wheel, vehicle.TirePressureMatchExact, true
 			}
-			foundStockWheel = true
-			break
 		}
 	}
 
-	if !foundStockWheel && len(vehicleData.Wheels) > 0 {
-		firstWheel := vehicleData.Wheels[0]
-		if firstWheel.Front.TirePressure != nil {
-			psi := firstWheel.Front.TirePressure.Psi
-			frontPsi = &psi
-		}
-		if firstWheel.Rear.TirePressure != nil {
-			psi := firstWheel.Rear.TirePressure.Psi
-			rearPsi = &psi
+	for _, wheel := range wheels {
+		if wheel.IsStock && wheel.hasTirePressure() {
+			return wheel, vehicle.TirePressureMatchStock, true
 		}
 	}
 
-	tirePressureResponse := vehicle.TirePressureResponse{
-		Source:   "wheel-size.com",
-		FrontPsi: frontPsi,
-		RearPsi:  rearPsi,
-		Unit:     "psi",
+	for _, wheel := range wheels {
+		if wheel.hasTirePressure() {
+			return wheel, vehicle.TirePressureMatchFirst, true
+		}
 	}
 
-	if frontPsi != nil || rearPsi != nil {
-		return tirePressureResponse, nil
+	return WheelSizeWheel{}, "", false
+}
+
+func (w WheelSizeWheel) hasTirePressure() bool {
+	return w.Front.TirePressure != nil || w.Rear.TirePressure != nil
+}
+
+// tireMatches compares a wheel-size tire marking against a registered tire size
+// by width, aspect ratio and rim diameter, ignoring the service description
+func tireMatches(tire string, registered *vehicle.TireSize) bool {
+	parsed, err := utils.ParseTireSize(tire)
+	if err != nil {
+		return false
 	}
 
-	return vehicle.TirePressureResponse{}, fmt.Errorf("%w: no tire pressure values present", serrors.ErrNoTirePressureData)
+	return parsed.Width == registered.Width &&
+		parsed.AspectRatio == registered.AspectRatio &&
+		parsed.RimDiameter == registered.RimDiameter
 }
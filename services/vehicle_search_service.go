@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+// VehicleSearchCriteria selects registry vehicles by model, year and color.
+// Empty fields and zero years are not filtered on.
+type VehicleSearchCriteria struct {
+	Manufacturer   string
+	CommercialName string
+	YearFrom       int
+	YearTo         int
+	Color          string
+	FuelType       string
+	Limit          int
+	Offset         int
+}
+
+// SearchVehicles runs a reverse lookup against the vehicle registry, following
+// Result.Links.Next until criteria.Limit vehicles were collected or the matches run out
+func (s *VehicleService) SearchVehicles(ctx context.Context, criteria VehicleSearchCriteria) (vehicle.VehicleSearchResponse, error) {
+	query, err := vehicleSearchQuery(criteria)
+	if err != nil {
+		return vehicle.VehicleSearchResponse{}, err
+	}
+
+	requestUrl, err := s.ckanClient.searchURL(config.VehicleResourceID, query)
+	if err != nil {
+		return vehicle.VehicleSearchResponse{}, fmt.Errorf("%w: error encoding search: %v", serrors.ErrFetchLicensePlate, err)
+	}
+
+	today := utils.RegistryToday()
+	searchResponse := vehicle.VehicleSearchResponse{Offset: criteria.Offset, Results: []vehicle.VehicleResponse{}}
+	for len(searchResponse.Results) < criteria.Limit {
+		page, err := fetchCKANResponse[vehicle.VehicleRecord](ctx, s.ckanClient.httpClient, requestUrl)
+		if err != nil {
+			return vehicle.VehicleSearchResponse{}, err
+		}
+		searchResponse.Total = page.Result.Total
+
+		for _, record := range page.Result.Records {
+			if len(searchResponse.Results) == criteria.Limit {
+				break
+			}
+
+			vehicleDetails, err := buildVehicleResponse(record)
+			if err != nil {
+				return vehicle.VehicleSearchResponse{}, err
+			}
+			if plate, err := utils.ParseLicensePlate(strconv.Itoa(record.LicenseNumber)); err == nil {
+				vehicleDetails.LicensePlate = plate.Canonical
+				vehicleDetails.LicensePlateDisplay = plate.Display
+			}
+			applyDerivedFields(&vehicleDetails, today)
+			searchResponse.Results = append(searchResponse.Results, vehicleDetails)
+		}
+
+		if len(page.Result.Records) < query.Limit || page.Result.Links.Next == "" {
+			break
+		}
+
+		requestUrl, err = s.ckanClient.resolveURL(page.Result.Links.Next)
+		if err != nil {
+			return vehicle.VehicleSearchResponse{}, fmt.Errorf("%w: invalid next page link: %v", serrors.ErrParseResponse, err)
+		}
+	}
+
+	if nextOffset := criteria.Offset + len(searchResponse.Results); nextOffset < searchResponse.Total {
+		searchResponse.NextOffset = &nextOffset
+	}
+
+	return searchResponse, nil
+}
+
+// vehicleSearchQuery maps the search criteria onto a CKAN datastore query.
+// Columns are matched exactly except for the manufacturer, which holds "name
+// country", and the color, whose shades such as "לבן שנהב" should match their
+// base color; those two use full-text search. English manufacturer, color and
+// fuel names are mapped back to the registry's Hebrew.
+func vehicleSearchQuery(criteria VehicleSearchCriteria) (ckanQuery, error) {
+	filters := map[string]any{}
+	fieldQuery := map[string]string{}
+
+	if manufacturer := strings.TrimSpace(criteria.Manufacturer); manufacturer != "" {
+		fieldQuery["tozeret_nm"] = utils.ConvertManufacturerToHebrew(manufacturer)
+	}
+	if commercialName := strings.TrimSpace(criteria.CommercialName); commercialName != "" {
+		filters["kinuy_mishari"] = strings.ToUpper(commercialName)
+	}
+	if color := strings.TrimSpace(criteria.Color); color != "" {
+		fieldQuery["tzeva_rechev"] = utils.ConvertColorToHebrew(color)
+	}
+	if fuelType := strings.TrimSpace(criteria.FuelType); fuelType != "" {
+		filters["sug_delek_nm"] = utils.ConvertFuelTypeToHebrew(fuelType)
+	}
+
+	if criteria.YearFrom > 0 || criteria.YearTo > 0 {
+		yearFrom, yearTo := criteria.YearFrom, criteria.YearTo
+		if yearFrom == 0 {
+			yearFrom = yearTo
+		}
+		if yearTo == 0 {
+			yearTo = yearFrom
+		}
+		if yearFrom > yearTo {
+			return ckanQuery{}, fmt.Errorf("%w: year_from %d is after year_to %d", serrors.ErrInvalidVehicleDetails, yearFrom, yearTo)
+		}
+		if yearTo-yearFrom >= config.VehicleSearchMaxYearSpan {
+			return ckanQuery{}, fmt.Errorf("%w: year range may span at most %d years", serrors.ErrInvalidVehicleDetails, config.VehicleSearchMaxYearSpan)
+		}
+
+		years := make([]int, 0, yearTo-yearFrom+1)
+		for year := yearFrom; year <= yearTo; year++ {
+			years = append(years, year)
+		}
+		filters["shnat_yitzur"] = years
+	}
+
+	if len(filters) == 0 && len(fieldQuery) == 0 {
+		return ckanQuery{}, fmt.Errorf("%w: at least one search criterion is required", serrors.ErrInvalidVehicleDetails)
+	}
+
+	return ckanQuery{
+		Filters:    filters,
+		FieldQuery: fieldQuery,
+		Limit:      min(criteria.Limit, config.VehicleSearchPageSize),
+		Offset:     criteria.Offset,
+	}, nil
+}
@@ -1,74 +1,67 @@
 package services
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"time"
 
-	config "car-license-number-fetcher/config"
 	vehicle "car-license-number-fetcher/models"
 	serrors "car-license-number-fetcher/serrors"
 	"car-license-number-fetcher/utils"
 )
 
-func FetchVehicleDetailsByLicensePlate(licensePlate string) (vehicle.VehicleResponse, error) {
-	requestUrl := fmt.Sprintf("%s%s", config.VehicleDataAPIEndpoint, licensePlate)
+// VehicleDataSource looks up a vehicle in a registry by its license plate
+type VehicleDataSource interface {
+	FetchVehicleDetails(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error)
+}
 
-	res, requestError := http.Get(requestUrl)
-	if requestError != nil {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w: %v", serrors.ErrFetchLicensePlate, requestError)
-	}
-	defer res.Body.Close()
+// VehicleService resolves vehicle details through a configured VehicleDataSource
+// and joins them against the other data.gov.il resources through ckanClient
+type VehicleService struct {
+	dataSource VehicleDataSource
+	ckanClient *CKANClient
+}
 
-	resBody, readingResponseError := io.ReadAll(res.Body)
-	if readingResponseError != nil {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w: %v", serrors.ErrParseResponse, readingResponseError)
-	}
+func NewVehicleService(dataSource VehicleDataSource, ckanClient *CKANClient) *VehicleService {
+	return &VehicleService{dataSource: dataSource, ckanClient: ckanClient}
+}
 
-	var v vehicle.VehicleDetails
-	if convertingToJsonError := json.Unmarshal(resBody, &v); convertingToJsonError != nil {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w: %v", serrors.ErrParseResponse, convertingToJsonError)
+func (s *VehicleService) FetchVehicleDetailsByLicensePlate(ctx context.Context, licensePlate string) (vehicle.VehicleResponse, error) {
+	plate, err := parseCivilianLicensePlate(licensePlate)
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
 	}
 
-	if !v.Success {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w", serrors.ErrResponseNotSuccessful)
+	vehicleDetails, err := s.dataSource.FetchVehicleDetails(ctx, plate.Canonical)
+	if err != nil {
+		return vehicle.VehicleResponse{}, err
 	}
+	vehicleDetails.LicensePlate = plate.Canonical
+	vehicleDetails.LicensePlateDisplay = plate.Display
+	applyDerivedFields(&vehicleDetails, utils.RegistryToday())
 
-	records := v.Result.Records
-	if len(records) == 0 {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w: no matching vehicle for license plate %s", serrors.ErrNoMatchingVehicle, licensePlate)
-	}
+	return vehicleDetails, nil
+}
 
-	record := records[0]
-	splitManufactureCountryCharacter := utils.GetSplitCharacter(record.ManufactureCountry)
-	manufacturerCountryAndName := strings.Split(record.ManufactureCountry, splitManufactureCountryCharacter)
+// applyDerivedFields fills the fields computed from the registry values rather than read from a registry
+func applyDerivedFields(vehicleDetails *vehicle.VehicleResponse, today time.Time) {
+	utils.ApplyComputedDateFields(vehicleDetails, today)
+	vehicleDetails.Decoded = utils.DecodeVehicleFields(*vehicleDetails)
+	vehicleDetails.FrontWheelSpec = utils.ParseTireSizeField(vehicleDetails.FrontWheel)
+	vehicleDetails.RearWheelSpec = utils.ParseTireSizeField(vehicleDetails.RearWheel)
+}
 
-	safetyFeaturesLevel, conversionError := utils.ParseSafetyFeaturesLevelField(record)
-	if conversionError != nil {
-		return vehicle.VehicleResponse{}, fmt.Errorf("%w: %v", serrors.ErrConvertSafetyFeaturesLevel, conversionError)
+// parseCivilianLicensePlate validates the plate and rejects the military, police
+// and diplomatic plates the public registries do not publish
+func parseCivilianLicensePlate(licensePlate string) (utils.LicensePlate, error) {
+	plate, err := utils.ParseLicensePlate(licensePlate)
+	if err != nil {
+		return utils.LicensePlate{}, err
 	}
 
-	vehicleDetails := vehicle.VehicleResponse{
-		LicenseNumber:       record.LicenseNumber,
-		ManufacturerCountry: manufacturerCountryAndName[1],
-		TrimLevel:           record.TrimLevel,
-		SafetyFeaturesLevel: safetyFeaturesLevel,
-		PollutionLevel:      record.PollutionLevel,
-		ManufacturYear:      record.ManufacturYear,
-		LastTestDate:        record.LastTestDate,
-		ValidDate:           record.ValidDate,
-		Ownership:           record.Ownership,
-		FrameNumber:         record.FrameNumber,
-		Color:               record.Color,
-		FrontWheel:          record.FrontWheel,
-		RearWheel:           record.RearWheel,
-		FuelType:            record.FuelType,
-		FirstOnRoadDate:     record.FirstOnRoadDate,
-		CommercialName:      record.CommercialName,
-		ManufacturerName:    manufacturerCountryAndName[0],
+	if plate.Type != utils.LicensePlateTypeCivilian {
+		return utils.LicensePlate{}, fmt.Errorf("%w: %s plate %s is not published in the civilian registry", serrors.ErrNoMatchingVehicle, plate.Type, plate.Display)
 	}
 
-	return vehicleDetails, nil
+	return plate, nil
 }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+)
+
+// WheelFitmentService lists the wheel and tire fitments of a vehicle's model
+type WheelFitmentService struct {
+	wheelSize *WheelSizeClient
+}
+
+func NewWheelFitmentService(wheelSize *WheelSizeClient) *WheelFitmentService {
+	return &WheelFitmentService{wheelSize: wheelSize}
+}
+
+// FetchWheelFitments returns every OEM and optional wheel fitment wheel-size
+// lists for the vehicle's model, with stock fitments flagged
+func (s *WheelFitmentService) FetchWheelFitments(ctx context.Context, vehicleDetails vehicle.VehicleResponse, options TirePressureOptions) (vehicle.WheelFitmentResponse, error) {
+	lookup, err := s.wheelSize.fetchVehicleData(ctx, vehicleDetails, options.Region)
+	if err != nil {
+		return vehicle.WheelFitmentResponse{}, err
+	}
+	vehicleData := lookup.Vehicle
+
+	unit := options.Unit
+	if unit == "" {
+		unit = vehicle.PressureUnitPsi
+	}
+
+	fitmentResponse := vehicle.WheelFitmentResponse{
+		Source:      config.TirePressureProviderWheelSize,
+		Model:       vehicleData.Name,
+		BoltPattern: vehicleData.Technical.BoltPattern,
+		CenterBore:  jsonValueText(vehicleData.Technical.CenterBore),
+		Region:      lookup.Region,
+		Resolution:  &lookup.Resolution,
+		Fitments:    make([]vehicle.WheelFitment, 0, len(vehicleData.Wheels)),
+	}
+
+	for _, wheel := range vehicleData.Wheels {
+		fitment := vehicle.WheelFitment{
+			IsStock: wheel.IsStock,
+			Front:   axleFitment(wheel.Front, unit),
+		}
+		if strings.TrimSpace(wheel.Rear.Tire) != "" {
+			rear := axleFitment(wheel.Rear, unit)
+			fitment.Rear = &rear
+		}
+		fitmentResponse.Fitments = append(fitmentResponse.Fitments, fitment)
+	}
+
+	return fitmentResponse, nil
+}
+
+func axleFitment(tireData WheelSizeTireData, unit string) vehicle.AxleFitment {
+	return vehicle.AxleFitment{
+		Tire:            tireData.Tire,
+		TireFull:        tireData.TireFull,
+		Rim:             tireData.Rim,
+		RimDiameter:     tireData.RimDiameter,
+		RimWidth:        tireData.RimWidth,
+		RimOffset:       tireData.RimOffset,
+		Pressure:        axlePressure(tireData.TirePressure, unit),
+		PressureMaxLoad: axlePressure(tireData.TirePressureMaxLoad, unit),
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+)
+
+// WheelSizeCatalogEntry represents a make or model of the wheel-size catalogue
+type WheelSizeCatalogEntry struct {
+	Slug   string `json:"slug"`
+	Name   string `json:"name"`
+	NameEn string `json:"name_en"`
+}
+
+type wheelSizeCatalogResponse struct {
+	Data []WheelSizeCatalogEntry `json:"data"`
+}
+
+type cachedCatalogEntries struct {
+	entries   []WheelSizeCatalogEntry
+	fetchedAt time.Time
+}
+
+// wheelSizeCatalog caches the wheel-size makes catalogue and the models of
+// each make in memory, refreshing a list once it is older than ttl
+type wheelSizeCatalog struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedCatalogEntries
+}
+
+func newWheelSizeCatalog(ttl time.Duration) *wheelSizeCatalog {
+	return &wheelSizeCatalog{ttl: ttl, entries: map[string]cachedCatalogEntries{}}
+}
+
+// resolveModel maps the registry manufacturer (already in English) and
+// commercial name onto the closest wheel-size make and model slugs
+func (c *WheelSizeClient) resolveModel(ctx context.Context, apiKey string, manufacturer string, commercialName string) (vehicle.WheelSizeResolution, error) {
+	makes, err := c.catalog.cached("makes", func() ([]WheelSizeCatalogEntry, error) {
+		return c.fetchCatalog(ctx, config.WheelSizeMakesEndpoint, url.Values{}, apiKey)
+	})
+	if err != nil {
+		return vehicle.WheelSizeResolution{}, err
+	}
+
+	matchedMake, makeConfidence := bestCatalogMatch(makes, manufacturer)
+	if makeConfidence < config.WheelSizeMinMatchConfidence {
+		return vehicle.WheelSizeResolution{}, fmt.Errorf("%w: no wheel-size make matches %q", serrors.ErrNoTirePressureData, manufacturer)
+	}
+
+	models, err := c.catalog.cached("models:"+matchedMake.Slug, func() ([]WheelSizeCatalogEntry, error) {
+		return c.fetchCatalog(ctx, config.WheelSizeModelsEndpoint, url.Values{"make": {matchedMake.Slug}}, apiKey)
+	})
+	if err != nil {
+		return vehicle.WheelSizeResolution{}, err
+	}
+
+	// Registry commercial names often repeat the make, as in "MAZDA 3"
+	matchedModel, modelConfidence := bestCatalogMatch(models, commercialName)
+	for _, prefix := range []string{manufacturer, matchedMake.Name, matchedMake.Slug} {
+		trimmed, found := trimCatalogPrefix(commercialName, prefix)
+		if !found {
+			continue
+		}
+		if candidate, confidence := bestCatalogMatch(models, trimmed); confidence > modelConfidence {
+			matchedModel, modelConfidence = candidate, confidence
+		}
+	}
+	if modelConfidence < config.WheelSizeMinMatchConfidence {
+		return vehicle.WheelSizeResolution{}, fmt.Errorf("%w: no wheel-size %s model matches %q", serrors.ErrNoTirePressureData, matchedMake.Slug, commercialName)
+	}
+
+	return vehicle.WheelSizeResolution{
+		MakeSlug:        matchedMake.Slug,
+		ModelSlug:       matchedModel.Slug,
+		MakeConfidence:  roundConfidence(makeConfidence),
+		ModelConfidence: roundConfidence(modelConfidence),
+	}, nil
+}
+
+// cached returns the catalogue list stored under key, calling fetch when it is missing or stale.
+// The lock is not held while fetching so a slow provider does not block lookups of other lists.
+func (c *wheelSizeCatalog) cached(key string, fetch func() ([]WheelSizeCatalogEntry, error)) ([]WheelSizeCatalogEntry, error) {
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < c.ttl {
+		return cached.entries, nil
+	}
+
+	entries, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cachedCatalogEntries{entries: entries, fetchedAt: time.Now()}
+	c.mu.Unlock()
+
+	return entries, nil
+}
+
+func (c *WheelSizeClient) fetchCatalog(ctx context.Context, endpoint string, params url.Values, apiKey string) ([]WheelSizeCatalogEntry, error) {
+	var catalogResponse wheelSizeCatalogResponse
+	if _, err := c.getJSON(ctx, endpoint, params, apiKey, &catalogResponse); err != nil {
+		return nil, err
+	}
+
+	return catalogResponse.Data, nil
+}
+
+// bestCatalogMatch returns the entry whose slug or name is closest to name, with its confidence between 0 and 1
+func bestCatalogMatch(entries []WheelSizeCatalogEntry, name string) (WheelSizeCatalogEntry, float64) {
+	var best WheelSizeCatalogEntry
+	bestConfidence := 0.0
+
+	for _, entry := range entries {
+		for _, candidate := range []string{entry.Slug, entry.Name, entry.NameEn} {
+			if confidence := matchConfidence(name, candidate); confidence > bestConfidence {
+				best, bestConfidence = entry, confidence
+			}
+		}
+	}
+
+	return best, bestConfidence
+}
+
+// matchConfidence scores how closely two catalogue names agree once case and
+// punctuation are ignored: 1 for equal names, 0.7 to 0.95 when one contains
+// the other, and the normalized edit distance otherwise
+func matchConfidence(query string, candidate string) float64 {
+	q, c := []rune(normalizeCatalogName(query)), []rune(normalizeCatalogName(candidate))
+	if len(q) == 0 || len(c) == 0 {
+		return 0
+	}
+	if string(q) == string(c) {
+		return 1
+	}
+
+	shorter, longer := len(q), len(c)
+	if shorter > longer {
+		shorter, longer = longer, shorter
+	}
+	if shorter >= 2 && (strings.Contains(string(q), string(c)) || strings.Contains(string(c), string(q))) {
+		return 0.7 + 0.25*float64(shorter)/float64(longer)
+	}
+
+	return 1 - float64(levenshteinDistance(q, c))/float64(longer)
+}
+
+// normalizeCatalogName lowercases name and drops everything but letters and digits
+func normalizeCatalogName(name string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// trimCatalogPrefix strips a leading make name from a commercial name, as in
+// "MAZDA 3" or "MAZDA3", but not from a longer word such as "MAZDASPEED"
+func trimCatalogPrefix(name string, prefix string) (string, bool) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || len(name) <= len(prefix) || !strings.EqualFold(name[:len(prefix)], prefix) {
+		return name, false
+	}
+
+	rest := name[len(prefix):]
+	if next, _ := utf8.DecodeRuneInString(rest); unicode.IsLetter(next) {
+		return name, false
+	}
+
+	trimmed := strings.TrimLeft(rest, " -")
+	if trimmed == "" {
+		return name, false
+	}
+	return trimmed, true
+}
+
+func levenshteinDistance(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func roundConfidence(confidence float64) float64 {
+	return math.Round(confidence*100) / 100
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatchConfidence(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		candidate string
+		want      float64
+	}{
+		{"equal", "mazda", "mazda", 1},
+		{"equal ignoring case and punctuation", "CX-5", "cx5", 1},
+		{"equal ignoring spaces", "Land Rover", "land-rover", 1},
+		{"query contains candidate", "mazda3", "mazda", 0.7 + 0.25*5/6},
+		{"candidate contains query", "golf", "golf plus", 0.7 + 0.25*4/8},
+		{"single character falls back to edit distance", "3", "cx-3", 1 - 2.0/3},
+		{"one edit apart", "corola", "corolla", 1 - 1.0/7},
+		{"unrelated", "abc", "xyz", 0},
+		{"empty query", "", "mazda", 0},
+		{"punctuation only", "--", "mazda", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchConfidence(tt.query, tt.candidate); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("matchConfidence(%q, %q) = %v, want %v", tt.query, tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimCatalogPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		commercial  string
+		prefix      string
+		want        string
+		wantTrimmed bool
+	}{
+		{"make and model", "MAZDA 3", "mazda", "3", true},
+		{"make joined to a number", "MAZDA3", "Mazda", "3", true},
+		{"dash separated", "BMW - X5", "bmw", "X5", true},
+		{"prefix with spaces", "LAND ROVER DEFENDER", " Land Rover ", "DEFENDER", true},
+		{"make is part of a longer word", "MAZDASPEED 6", "mazda", "MAZDASPEED 6", false},
+		{"different make", "COROLLA", "toyota", "COROLLA", false},
+		{"only the make", "MAZDA", "mazda", "MAZDA", false},
+		{"only the make and a separator", "MAZDA -", "mazda", "MAZDA -", false},
+		{"empty prefix", "MAZDA 3", "", "MAZDA 3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, trimmed := trimCatalogPrefix(tt.commercial, tt.prefix)
+			if got != tt.want || trimmed != tt.wantTrimmed {
+				t.Errorf("trimCatalogPrefix(%q, %q) = %q, %v, want %q, %v", tt.commercial, tt.prefix, got, trimmed, tt.want, tt.wantTrimmed)
+			}
+		})
+	}
+}
+
+func TestBestCatalogMatch(t *testing.T) {
+	models := []WheelSizeCatalogEntry{
+		{Slug: "3", Name: "3"},
+		{Slug: "cx-3", Name: "CX-3"},
+		{Slug: "cx-30", Name: "CX-30"},
+		{Slug: "mx-5", Name: "MX-5", NameEn: "MX-5 Miata"},
+	}
+
+	tests := []struct {
+		name           string
+		commercialName string
+		wantSlug       string
+		wantConfidence float64
+	}{
+		{"exact slug", "3", "3", 1},
+		{"exact name", "CX 30", "cx-30", 1},
+		{"english name", "miata", "mx-5", 0.7 + 0.25*5/8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, confidence := bestCatalogMatch(models, tt.commercialName)
+			if got.Slug != tt.wantSlug || math.Abs(confidence-tt.wantConfidence) > 1e-9 {
+				t.Errorf("bestCatalogMatch(%q) = %q at %v, want %q at %v", tt.commercialName, got.Slug, confidence, tt.wantSlug, tt.wantConfidence)
+			}
+		})
+	}
+}
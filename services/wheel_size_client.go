@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+	serrors "car-license-number-fetcher/serrors"
+	"car-license-number-fetcher/utils"
+)
+
+// WheelSizeClient queries the wheel-size.com API for a vehicle's model and
+// caches the provider's make and model catalogue it resolves names against
+type WheelSizeClient struct {
+	httpClient *http.Client
+	catalog    *wheelSizeCatalog
+}
+
+func NewWheelSizeClient(httpClient *http.Client) *WheelSizeClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &WheelSizeClient{
+		httpClient: httpClient,
+		catalog:    newWheelSizeCatalog(config.WheelSizeCatalogTTLHours * time.Hour),
+	}
+}
+
+// wheelSizeLookup is a wheel-size model together with the catalogue slugs and
+// market it was looked up by
+type wheelSizeLookup struct {
+	Vehicle    WheelSizeVehicleData
+	Resolution vehicle.WheelSizeResolution
+	// Region is the market the model was found in and PreferredRegion the one
+	// asked for first; they differ when the lookup fell back to another market
+	Region          string
+	PreferredRegion string
+	// Raw is the by_model response body the model was read from
+	Raw json.RawMessage
+}
+
+// fetchVehicleData resolves the vehicle's make and model to wheel-size
+// slugs and queries wheel-size for them and the year in the requested region,
+// or else in the market inferred from the manufacturer country followed by the
+// other markets wheel-size reports data for. It returns the first matching model.
+func (c *WheelSizeClient) fetchVehicleData(ctx context.Context, vehicleDetails vehicle.VehicleResponse, region string) (wheelSizeLookup, error) {
+	apiKey := os.Getenv(config.WheelSizeAPIKeyEnvVar)
+	if apiKey == "" {
+		return wheelSizeLookup{}, fmt.Errorf("%w: %s environment variable is not set", serrors.ErrInvalidVehicleDetails, config.WheelSizeAPIKeyEnvVar)
+	}
+	commercial := strings.TrimSpace(vehicleDetails.CommercialName)
+	if commercial == "" {
+		return wheelSizeLookup{}, fmt.Errorf("%w: commercial name (model) is empty", serrors.ErrInvalidVehicleDetails)
+	}
+	if vehicleDetails.ManufacturYear <= 0 {
+		return wheelSizeLookup{}, fmt.Errorf("%w: invalid manufacture year: %d", serrors.ErrInvalidVehicleDetails, vehicleDetails.ManufacturYear)
+	}
+
+	englishManufacturer := utils.ConvertManufacturerToEnglish(vehicleDetails.ManufacturerName)
+
+	if englishManufacturer == "" {
+		return wheelSizeLookup{}, fmt.Errorf("%w: manufacturer empty or not mapped: %q", serrors.ErrInvalidVehicleDetails, vehicleDetails.ManufacturerName)
+	}
+
+	resolution, err := c.resolveModel(ctx, apiKey, englishManufacturer, commercial)
+	if err != nil {
+		return wheelSizeLookup{}, err
+	}
+
+	explicitRegion := region != ""
+	if !explicitRegion {
+		region = utils.WheelSizeRegionForCountry(vehicleDetails.ManufacturerCountry)
+	}
+
+	wheelSizeResponse, raw, err := c.searchByModel(ctx, apiKey, resolution, vehicleDetails.ManufacturYear, region)
+	if err != nil {
+		return wheelSizeLookup{}, err
+	}
+
+	lookup := wheelSizeLookup{Resolution: resolution, Region: region, PreferredRegion: region}
+	if len(wheelSizeResponse.Data) > 0 {
+		lookup.Vehicle = wheelSizeResponse.Data[0]
+		lookup.Raw = raw
+		return lookup, nil
+	}
+
+	if !explicitRegion {
+		for _, fallbackRegion := range fallbackRegions(wheelSizeResponse.Meta.Regions, region) {
+			fallbackResponse, fallbackRaw, err := c.searchByModel(ctx, apiKey, resolution, vehicleDetails.ManufacturYear, fallbackRegion)
+			if err != nil {
+				return wheelSizeLookup{}, err
+			}
+			if len(fallbackResponse.Data) > 0 {
+				lookup.Vehicle = fallbackResponse.Data[0]
+				lookup.Region = fallbackRegion
+				lookup.Raw = fallbackRaw
+				return lookup, nil
+			}
+		}
+	}
+
+	return wheelSizeLookup{}, fmt.Errorf("%w: no vehicle data found in region %s", serrors.ErrNoTirePressureData, region)
+}
+
+func (c *WheelSizeClient) searchByModel(ctx context.Context, apiKey string, resolution vehicle.WheelSizeResolution, year int, region string) (WheelSizeAPIResponse, json.RawMessage, error) {
+	params := url.Values{}
+	params.Add("make", resolution.MakeSlug)
+	params.Add("model", resolution.ModelSlug)
+	params.Add("year", fmt.Sprintf("%d", year))
+	params.Add("region", region)
+
+	var wheelSizeResponse WheelSizeAPIResponse
+	raw, err := c.getJSON(ctx, config.WheelSizeAPIEndpoint, params, apiKey, &wheelSizeResponse)
+	if err != nil {
+		return WheelSizeAPIResponse{}, nil, err
+	}
+
+	return wheelSizeResponse, raw, nil
+}
+
+// fallbackRegions lists the markets wheel-size reports data for other than
+// tried, those with the most matching models first
+func fallbackRegions(regionCounts map[string]int, tried string) []string {
+	regions := make([]string, 0, len(regionCounts))
+	for region, count := range regionCounts {
+		if region != tried && count > 0 {
+			regions = append(regions, region)
+		}
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		if regionCounts[regions[i]] != regionCounts[regions[j]] {
+			return regionCounts[regions[i]] > regionCounts[regions[j]]
+		}
+		return regions[i] < regions[j]
+	})
+
+	return regions
+}
+
+// getJSON runs an authenticated GET against a wheel-size endpoint,
+// decodes a successful response into v and returns the raw body
+func (c *WheelSizeClient) getJSON(ctx context.Context, endpoint string, params url.Values, apiKey string, v any) ([]byte, error) {
+	baseURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error parsing wheel-size API endpoint: %v", serrors.ErrFetchTirePressure, err)
+	}
+
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("user_key", apiKey)
+	baseURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error creating request: %v", serrors.ErrFetchTirePressure, err)
+	}
+
+	req.Header.Set("accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		// The url.Error message carries the request URL, user_key included
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%w: %s %s: %v", serrors.ErrFetchTirePressure, http.MethodGet, endpoint, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading response body: %v", serrors.ErrParseResponse, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d", serrors.ErrResponseNotSuccessful, res.StatusCode)
+	}
+
+	if err := json.Unmarshal(resBody, v); err != nil {
+		return nil, fmt.Errorf("%w: %v", serrors.ErrParseResponse, err)
+	}
+
+	return resBody, nil
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
+)
+
+// registryDateLayouts are the date formats found across the data.gov.il
+// resources, from full timestamps down to the year-month of moed_aliya_lakvish
+// and baalut_dt
+var registryDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	time.DateTime,
+	time.DateOnly,
+	"2006-1-2",
+	"02/01/2006",
+	"20060102",
+	"2006-01",
+	"2006-1",
+	"200601",
+}
+
+// ParseRegistryDate parses a registry date string. Unparsable and empty values
+// yield the zero time.
+func ParseRegistryDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range registryDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
+
+// RegistryDate parses a registry date string into the response date type
+func RegistryDate(value string) *vehicle.Date {
+	return vehicle.NewDate(ParseRegistryDate(value))
+}
+
+// RegistryToday returns the current date in the registry's time zone, as a
+// UTC midnight comparable with parsed registry dates
+func RegistryToday() time.Time {
+	now := time.Now()
+	if location, err := time.LoadLocation(config.RegistryTimeZone); err == nil {
+		now = now.In(location)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ApplyComputedDateFields fills the fields derived from the vehicle's dates
+// relative to today: test validity and vehicle age
+func ApplyComputedDateFields(vehicleDetails *vehicle.VehicleResponse, today time.Time) {
+	if vehicleDetails.ValidDate != nil {
+		daysUntilTestExpiry := int(vehicleDetails.ValidDate.Sub(today).Hours() / 24)
+		testExpired := daysUntilTestExpiry < 0
+		vehicleDetails.DaysUntilTestExpiry = &daysUntilTestExpiry
+		vehicleDetails.TestExpired = &testExpired
+	}
+
+	var since time.Time
+	switch {
+	case vehicleDetails.FirstOnRoadDate != nil:
+		since = vehicleDetails.FirstOnRoadDate.Time
+	case vehicleDetails.ManufacturYear > 0:
+		since = time.Date(vehicleDetails.ManufacturYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return
+	}
+
+	vehicleAgeYears := today.Year() - since.Year()
+	if today.Month() < since.Month() || (today.Month() == since.Month() && today.Day() < since.Day()) {
+		vehicleAgeYears--
+	}
+	vehicleAgeYears = max(vehicleAgeYears, 0)
+	vehicleDetails.VehicleAgeYears = &vehicleAgeYears
+}
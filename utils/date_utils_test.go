@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+func TestParseRegistryDate(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Time
+	}{
+		{"timestamp", "2023-05-11T00:00:00", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"timestamp with fraction", "2023-05-11T08:30:00.123", time.Date(2023, time.May, 11, 8, 30, 0, 123000000, time.UTC)},
+		{"space separated timestamp", "2023-05-11 00:00:00", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"date", "2023-05-11", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"unpadded date", "2023-5-1", time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{"day first date", "11/05/2023", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"compact date", "20230511", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"year month", "2019-03", time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"unpadded year month", "2019-3", time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"compact year month", "201903", time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"surrounding spaces", " 2023-05-11 ", time.Date(2023, time.May, 11, 0, 0, 0, 0, time.UTC)},
+		{"empty", "", time.Time{}},
+		{"garbage", "not a date", time.Time{}},
+		{"invalid day", "2023-02-30", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRegistryDate(tt.value); !got.Equal(tt.want) {
+				t.Errorf("ParseRegistryDate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyComputedDateFields(t *testing.T) {
+	today := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day int) *vehicle.Date {
+		return &vehicle.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	tests := []struct {
+		name                string
+		vehicleDetails      vehicle.VehicleResponse
+		daysUntilTestExpiry *int
+		testExpired         *bool
+		vehicleAgeYears     *int
+	}{
+		{
+			name:                "test valid until next month",
+			vehicleDetails:      vehicle.VehicleResponse{ValidDate: date(2024, time.July, 15)},
+			daysUntilTestExpiry: intPointer(30),
+			testExpired:         boolPointer(false),
+		},
+		{
+			name:                "test expires today",
+			vehicleDetails:      vehicle.VehicleResponse{ValidDate: date(2024, time.June, 15)},
+			daysUntilTestExpiry: intPointer(0),
+			testExpired:         boolPointer(false),
+		},
+		{
+			name:                "test expired yesterday",
+			vehicleDetails:      vehicle.VehicleResponse{ValidDate: date(2024, time.June, 14)},
+			daysUntilTestExpiry: intPointer(-1),
+			testExpired:         boolPointer(true),
+		},
+		{
+			name:            "age on the anniversary",
+			vehicleDetails:  vehicle.VehicleResponse{FirstOnRoadDate: date(2019, time.June, 15)},
+			vehicleAgeYears: intPointer(5),
+		},
+		{
+			name:            "age the day before the anniversary",
+			vehicleDetails:  vehicle.VehicleResponse{FirstOnRoadDate: date(2019, time.June, 16)},
+			vehicleAgeYears: intPointer(4),
+		},
+		{
+			name:            "age from the manufacture year",
+			vehicleDetails:  vehicle.VehicleResponse{ManufacturYear: 2020},
+			vehicleAgeYears: intPointer(4),
+		},
+		{
+			name:            "first on road date preferred over the manufacture year",
+			vehicleDetails:  vehicle.VehicleResponse{FirstOnRoadDate: date(2021, time.January, 1), ManufacturYear: 2020},
+			vehicleAgeYears: intPointer(3),
+		},
+		{
+			name:            "future date is not a negative age",
+			vehicleDetails:  vehicle.VehicleResponse{ManufacturYear: 2025},
+			vehicleAgeYears: intPointer(0),
+		},
+		{
+			name:           "no dates",
+			vehicleDetails: vehicle.VehicleResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicleDetails := tt.vehicleDetails
+			ApplyComputedDateFields(&vehicleDetails, today)
+
+			if !equalPointers(vehicleDetails.DaysUntilTestExpiry, tt.daysUntilTestExpiry) {
+				t.Errorf("DaysUntilTestExpiry = %v, want %v", formatPointer(vehicleDetails.DaysUntilTestExpiry), formatPointer(tt.daysUntilTestExpiry))
+			}
+			if !equalPointers(vehicleDetails.TestExpired, tt.testExpired) {
+				t.Errorf("TestExpired = %v, want %v", formatPointer(vehicleDetails.TestExpired), formatPointer(tt.testExpired))
+			}
+			if !equalPointers(vehicleDetails.VehicleAgeYears, tt.vehicleAgeYears) {
+				t.Errorf("VehicleAgeYears = %v, want %v", formatPointer(vehicleDetails.VehicleAgeYears), formatPointer(tt.vehicleAgeYears))
+			}
+		})
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func equalPointers[T comparable](got *T, want *T) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return *got == *want
+}
+
+func formatPointer[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
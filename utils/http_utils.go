@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	config "car-license-number-fetcher/config"
+	vehicle "car-license-number-fetcher/models"
 	serrors "car-license-number-fetcher/serrors"
 
 	"github.com/gin-gonic/gin"
@@ -15,24 +16,44 @@ func RespondWithError(c *gin.Context, code int, err error) {
 }
 
 func HandleVehicleDetailsError(c *gin.Context, err error, licensePlate string) {
+	var scrappedErr *vehicle.ScrappedVehicleError
+	if errors.As(err, &scrappedErr) {
+		c.JSON(http.StatusGone, struct {
+			Error string `json:"error"`
+			*vehicle.ScrappedVehicleError
+		}{Error: err.Error(), ScrappedVehicleError: scrappedErr})
+		return
+	}
+
+	RespondWithError(c, VehicleDetailsErrorStatus(err), err)
+}
+
+// VehicleDetailsErrorStatus classifies a lookup error into the HTTP status it is reported with
+func VehicleDetailsErrorStatus(err error) int {
 	switch {
 	case errors.Is(err, serrors.ErrFetchLicensePlate),
 	     errors.Is(err, serrors.ErrFetchTirePressure):
-		RespondWithError(c, http.StatusBadGateway, err)
+		return http.StatusBadGateway
 
 	case errors.Is(err, serrors.ErrInvalidVehicleDetails):
-		RespondWithError(c, http.StatusBadRequest, err)
+		return http.StatusBadRequest
 
 	case errors.Is(err, serrors.ErrParseResponse),
 	     errors.Is(err, serrors.ErrConvertSafetyFeaturesLevel):
-		RespondWithError(c, http.StatusInternalServerError, err)
+		return http.StatusInternalServerError
 
 	case errors.Is(err, serrors.ErrResponseNotSuccessful),
 	     errors.Is(err, serrors.ErrNoMatchingVehicle),
 	     errors.Is(err, serrors.ErrNoTirePressureData):
-		RespondWithError(c, http.StatusNotFound, err)
+		return http.StatusNotFound
+
+	case errors.Is(err, serrors.ErrVehicleScrapped):
+		return http.StatusGone
+
+	case errors.Is(err, serrors.ErrAmbiguousVehicle):
+		return http.StatusConflict
 
 	default:
-		RespondWithError(c, http.StatusInternalServerError, err)
+		return http.StatusInternalServerError
 	}
 }
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	serrors "car-license-number-fetcher/serrors"
+)
+
+const (
+	LicensePlateTypeCivilian   = "civilian"
+	LicensePlateTypeMilitary   = "military"
+	LicensePlateTypePolice     = "police"
+	LicensePlateTypeDiplomatic = "diplomatic"
+)
+
+// LicensePlate is a parsed Israeli license plate. Canonical is the form the
+// registries are keyed by and Display the form printed on the plate.
+type LicensePlate struct {
+	Canonical string
+	Display   string
+	Type      string
+}
+
+var (
+	civilianPlatePattern   = regexp.MustCompile(`^\d{7,8}$`)
+	militaryPlatePattern   = regexp.MustCompile(`^צ(\d{5,7})$`)
+	policePlatePattern     = regexp.MustCompile(`^(\d{4,6})מ$`)
+	diplomaticPlatePattern = regexp.MustCompile(`^(\d{3,6})(CD|CC)$`)
+)
+
+// ParseLicensePlate strips the dashes, spaces and dots users type between the
+// plate groups and recognizes the civilian 7 and 8 digit formats along with
+// military, police and diplomatic plates
+func ParseLicensePlate(licensePlate string) (LicensePlate, error) {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "", ".", "").Replace(strings.TrimSpace(licensePlate)))
+
+	if civilianPlatePattern.MatchString(normalized) {
+		return LicensePlate{
+			Canonical: normalized,
+			Display:   formatCivilianLicensePlate(normalized),
+			Type:      LicensePlateTypeCivilian,
+		}, nil
+	}
+
+	if match := militaryPlatePattern.FindStringSubmatch(normalized); match != nil {
+		return LicensePlate{Canonical: normalized, Display: "צ-" + match[1], Type: LicensePlateTypeMilitary}, nil
+	}
+
+	if match := policePlatePattern.FindStringSubmatch(normalized); match != nil {
+		return LicensePlate{Canonical: normalized, Display: match[1] + "-מ", Type: LicensePlateTypePolice}, nil
+	}
+
+	if match := diplomaticPlatePattern.FindStringSubmatch(normalized); match != nil {
+		return LicensePlate{Canonical: normalized, Display: match[1] + "-" + match[2], Type: LicensePlateTypeDiplomatic}, nil
+	}
+
+	return LicensePlate{}, fmt.Errorf("%w: %q is not a valid license plate", serrors.ErrInvalidVehicleDetails, licensePlate)
+}
+
+// formatCivilianLicensePlate groups a 7 digit plate as 12-345-67 and an 8 digit plate as 123-45-678
+func formatCivilianLicensePlate(digits string) string {
+	if len(digits) == 7 {
+		return digits[:2] + "-" + digits[2:5] + "-" + digits[5:]
+	}
+	return digits[:3] + "-" + digits[3:5] + "-" + digits[5:]
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	serrors "car-license-number-fetcher/serrors"
+)
+
+func TestParseLicensePlate(t *testing.T) {
+	tests := []struct {
+		name         string
+		licensePlate string
+		want         LicensePlate
+	}{
+		{"7 digit civilian", "1234567", LicensePlate{Canonical: "1234567", Display: "12-345-67", Type: LicensePlateTypeCivilian}},
+		{"8 digit civilian", "12345678", LicensePlate{Canonical: "12345678", Display: "123-45-678", Type: LicensePlateTypeCivilian}},
+		{"civilian with dashes", "12-345-67", LicensePlate{Canonical: "1234567", Display: "12-345-67", Type: LicensePlateTypeCivilian}},
+		{"civilian with spaces and dots", " 123 45.678 ", LicensePlate{Canonical: "12345678", Display: "123-45-678", Type: LicensePlateTypeCivilian}},
+		{"military", "צ-123456", LicensePlate{Canonical: "צ123456", Display: "צ-123456", Type: LicensePlateTypeMilitary}},
+		{"police", "12345-מ", LicensePlate{Canonical: "12345מ", Display: "12345-מ", Type: LicensePlateTypePolice}},
+		{"diplomatic", "123-CD", LicensePlate{Canonical: "123CD", Display: "123-CD", Type: LicensePlateTypeDiplomatic}},
+		{"lowercase diplomatic", "4567cc", LicensePlate{Canonical: "4567CC", Display: "4567-CC", Type: LicensePlateTypeDiplomatic}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLicensePlate(tt.licensePlate)
+			if err != nil {
+				t.Fatalf("ParseLicensePlate(%q) returned error: %v", tt.licensePlate, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLicensePlate(%q) = %+v, want %+v", tt.licensePlate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLicensePlateInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		licensePlate string
+	}{
+		{"empty", ""},
+		{"too short", "123456"},
+		{"too long", "123456789"},
+		{"letters", "12AB567"},
+		{"short military", "צ-1234"},
+		{"short police", "123-מ"},
+		{"unknown diplomatic suffix", "123-CX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLicensePlate(tt.licensePlate)
+			if !errors.Is(err, serrors.ErrInvalidVehicleDetails) {
+				t.Errorf("ParseLicensePlate(%q) error = %v, want %v", tt.licensePlate, err, serrors.ErrInvalidVehicleDetails)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+const (
+	LanguageHebrew  = "he"
+	LanguageEnglish = "en"
+	LanguageArabic  = "ar"
+	LanguageRussian = "ru"
+)
+
+// supportedLanguages lists the languages vehicle labels are translated to. Hebrew is the fallback.
+var supportedLanguages = map[string]struct{}{
+	LanguageHebrew:  {},
+	LanguageEnglish: {},
+	LanguageArabic:  {},
+	LanguageRussian: {},
+}
+
+// VehicleFieldLabels holds the display label of each vehicle response field per language
+var VehicleFieldLabels = map[string]map[string]string{
+	"license_plate_display":  {"he": "מספר רישוי", "en": "License plate", "ar": "رقم الترخيص", "ru": "Госномер"},
+	"manufacturer_name":      {"he": "יצרן", "en": "Manufacturer", "ar": "الشركة المصنعة", "ru": "Производитель"},
+	"commercial_name":        {"he": "כינוי מסחרי", "en": "Model", "ar": "الطراز", "ru": "Модель"},
+	"manufacturer_country":   {"he": "ארץ ייצור", "en": "Country of manufacture", "ar": "بلد الصنع", "ru": "Страна производства"},
+	"trim_level":             {"he": "רמת גימור", "en": "Trim level", "ar": "مستوى التجهيز", "ru": "Комплектация"},
+	"year_of_production":     {"he": "שנת ייצור", "en": "Year of production", "ar": "سنة الصنع", "ru": "Год выпуска"},
+	"color":                  {"he": "צבע", "en": "Color", "ar": "اللون", "ru": "Цвет"},
+	"fuel_type":              {"he": "סוג דלק", "en": "Fuel type", "ar": "نوع الوقود", "ru": "Тип топлива"},
+	"ownership":              {"he": "בעלות", "en": "Ownership", "ar": "الملكية", "ru": "Владение"},
+	"last_test_date":         {"he": "טסט אחרון", "en": "Last inspection", "ar": "آخر فحص", "ru": "Последний техосмотр"},
+	"valid_date":             {"he": "תוקף רישיון", "en": "License valid until", "ar": "الترخيص ساري حتى", "ru": "Регистрация действительна до"},
+	"days_until_test_expiry": {"he": "ימים עד פקיעת הטסט", "en": "Days until inspection expires", "ar": "أيام حتى انتهاء الفحص", "ru": "Дней до окончания техосмотра"},
+	"test_expired":           {"he": "תוקף הטסט פג", "en": "Inspection expired", "ar": "انتهت صلاحية الفحص", "ru": "Техосмотр просрочен"},
+	"first_on_road_date":     {"he": "עלייה לכביש", "en": "First registration", "ar": "أول تسجيل", "ru": "Первая регистрация"},
+	"vehicle_age_years":      {"he": "גיל הרכב (שנים)", "en": "Vehicle age (years)", "ar": "عمر المركبة (سنوات)", "ru": "Возраст автомобиля (лет)"},
+	"frame_number":           {"he": "מספר שלדה", "en": "VIN", "ar": "رقم الشاصي", "ru": "VIN"},
+	"front_wheel":            {"he": "צמיג קדמי", "en": "Front tire", "ar": "الإطار الأمامي", "ru": "Передняя шина"},
+	"rear_wheel":             {"he": "צמיג אחורי", "en": "Rear tire", "ar": "الإطار الخلفي", "ru": "Задняя шина"},
+	"pollution_level":        {"he": "קבוצת זיהום", "en": "Pollution group", "ar": "مجموعة التلوث", "ru": "Группа загрязнения"},
+	"safety_feature_level":   {"he": "רמת אבזור בטיחותי", "en": "Safety equipment level", "ar": "مستوى تجهيزات الأمان", "ru": "Уровень оснащения безопасности"},
+	"status":                 {"he": "סטטוס", "en": "Status", "ar": "الحالة", "ru": "Статус"},
+	"has_disabled_permit":    {"he": "תג נכה", "en": "Disabled parking permit", "ar": "تصريح وقوف لذوي الإعاقة", "ru": "Разрешение на парковку для инвалидов"},
+}
+
+// FuelTypeTranslations holds the display name of each decoded fuel type per language
+var FuelTypeTranslations = map[string]map[string]string{
+	"petrol":        {"he": "בנזין", "en": "Petrol", "ar": "بنزين", "ru": "Бензин"},
+	"diesel":        {"he": "דיזל", "en": "Diesel", "ar": "ديزل", "ru": "Дизель"},
+	"electric":      {"he": "חשמל", "en": "Electric", "ar": "كهربائي", "ru": "Электро"},
+	"hybrid_petrol": {"he": "היברידי בנזין", "en": "Petrol hybrid", "ar": "هجين بنزين", "ru": "Гибрид (бензин)"},
+	"hybrid_diesel": {"he": "היברידי דיזל", "en": "Diesel hybrid", "ar": "هجين ديزل", "ru": "Гибрид (дизель)"},
+	"lpg":           {"he": "גפ\"מ", "en": "LPG", "ar": "غاز مسال", "ru": "Газ (LPG)"},
+	"petrol_lpg":    {"he": "בנזין/גפ\"מ", "en": "Petrol/LPG", "ar": "بنزين/غاز مسال", "ru": "Бензин/газ"},
+	"cng":           {"he": "גז טבעי דחוס", "en": "CNG", "ar": "غاز طبيعي مضغوط", "ru": "Метан (CNG)"},
+	"hydrogen":      {"he": "מימן", "en": "Hydrogen", "ar": "هيدروجين", "ru": "Водород"},
+}
+
+// OwnershipTranslations holds the display name of each decoded ownership type per language
+var OwnershipTranslations = map[string]map[string]string{
+	"private":    {"he": "פרטי", "en": "Private", "ar": "خاص", "ru": "Частная"},
+	"leasing":    {"he": "ליסינג", "en": "Leasing", "ar": "تأجير تمويلي", "ru": "Лизинг"},
+	"rental":     {"he": "השכרה", "en": "Rental", "ar": "تأجير", "ru": "Прокат"},
+	"company":    {"he": "חברה", "en": "Company", "ar": "شركة", "ru": "Компания"},
+	"government": {"he": "ממשלתי", "en": "Government", "ar": "حكومي", "ru": "Государственная"},
+	"dealer":     {"he": "סוחר", "en": "Dealer", "ar": "تاجر", "ru": "Дилер"},
+}
+
+// ColorTranslations holds the display name of each decoded base color per language
+var ColorTranslations = map[string]map[string]string{
+	"white":      {"he": "לבן", "en": "White", "ar": "أبيض", "ru": "Белый"},
+	"black":      {"he": "שחור", "en": "Black", "ar": "أسود", "ru": "Чёрный"},
+	"silver":     {"he": "כסף", "en": "Silver", "ar": "فضي", "ru": "Серебристый"},
+	"gray":       {"he": "אפור", "en": "Gray", "ar": "رمادي", "ru": "Серый"},
+	"blue":       {"he": "כחול", "en": "Blue", "ar": "أزرق", "ru": "Синий"},
+	"light_blue": {"he": "תכלת", "en": "Light blue", "ar": "أزرق فاتح", "ru": "Голубой"},
+	"red":        {"he": "אדום", "en": "Red", "ar": "أحمر", "ru": "Красный"},
+	"burgundy":   {"he": "בורדו", "en": "Burgundy", "ar": "خمري", "ru": "Бордовый"},
+	"green":      {"he": "ירוק", "en": "Green", "ar": "أخضر", "ru": "Зелёный"},
+	"yellow":     {"he": "צהוב", "en": "Yellow", "ar": "أصفر", "ru": "Жёлтый"},
+	"orange":     {"he": "כתום", "en": "Orange", "ar": "برتقالي", "ru": "Оранжевый"},
+	"brown":      {"he": "חום", "en": "Brown", "ar": "بني", "ru": "Коричневый"},
+	"beige":      {"he": "בז'", "en": "Beige", "ar": "بيج", "ru": "Бежевый"},
+	"gold":       {"he": "זהב", "en": "Gold", "ar": "ذهبي", "ru": "Золотистый"},
+	"ivory":      {"he": "שנהב", "en": "Ivory", "ar": "عاجي", "ru": "Слоновая кость"},
+	"purple":     {"he": "סגול", "en": "Purple", "ar": "بنفسجي", "ru": "Фиолетовый"},
+	"pink":       {"he": "ורוד", "en": "Pink", "ar": "وردي", "ru": "Розовый"},
+}
+
+// ParseLanguage picks the supported language the Accept-Language header prefers
+// most, honoring q weights, and falls back to Hebrew
+func ParseLanguage(acceptLanguage string) string {
+	type weightedLanguage struct {
+		language string
+		weight   float64
+	}
+
+	var candidates []weightedLanguage
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "iw" {
+			language = LanguageHebrew
+		}
+		if _, supported := supportedLanguages[language]; !supported {
+			continue
+		}
+
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if weight > 0 {
+			candidates = append(candidates, weightedLanguage{language: language, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return LanguageHebrew
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+	return candidates[0].language
+}
+
+// LocalizeVehicleResponse adds the field labels and the localized color, fuel
+// type and ownership values in language. Values without a translation keep
+// the registry's Hebrew label.
+func LocalizeVehicleResponse(vehicleDetails *vehicle.VehicleResponse, language string) {
+	vehicleDetails.Language = language
+
+	vehicleDetails.Labels = make(map[string]string, len(VehicleFieldLabels))
+	for field, translations := range VehicleFieldLabels {
+		vehicleDetails.Labels[field] = translations[language]
+	}
+
+	if vehicleDetails.Decoded == nil {
+		return
+	}
+	localizeLabeledValue(vehicleDetails.Decoded.FuelType, FuelTypeTranslations, language)
+	localizeLabeledValue(vehicleDetails.Decoded.Ownership, OwnershipTranslations, language)
+	localizeLabeledValue(vehicleDetails.Decoded.Color, ColorTranslations, language)
+}
+
+func localizeLabeledValue(labeledValue *vehicle.LabeledValue, translations map[string]map[string]string, language string) {
+	if labeledValue == nil {
+		return
+	}
+
+	if translated, found := translations[labeledValue.Value][language]; found {
+		labeledValue.Localized = translated
+		return
+	}
+	labeledValue.Localized = labeledValue.Label
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"unicode"
@@ -90,3 +91,27 @@ func isEnglish(s string) bool {
 	}
 	return true
 }
+
+// ConvertManufacturerToHebrew maps an English manufacturer name back to the
+// Hebrew name the registry uses. Hebrew and unmapped names are returned as is.
+func ConvertManufacturerToHebrew(manufacturerName string) string {
+	manufacturerName = strings.TrimSpace(manufacturerName)
+	if !isEnglish(manufacturerName) {
+		return manufacturerName
+	}
+
+	var hebrewNames []string
+	for hebrewName, englishName := range HebrewToEnglishManufacturerMap {
+		if strings.EqualFold(englishName, manufacturerName) && !isEnglish(hebrewName) {
+			hebrewNames = append(hebrewNames, hebrewName)
+		}
+	}
+
+	if len(hebrewNames) == 0 {
+		return manufacturerName
+	}
+
+	// Several spellings can map to one manufacturer; pick one deterministically
+	sort.Strings(hebrewNames)
+	return hebrewNames[0]
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"strings"
+
+	config "car-license-number-fetcher/config"
+)
+
+// HebrewCountryToWheelSizeRegionMap maps the registry's manufacturer country
+// onto the wheel-size market whose specs the car was most likely built to
+var HebrewCountryToWheelSizeRegionMap = map[string]string{
+	"יפן":         "jdm",
+	"ארה\"ב":      "usdm",
+	"ארצות הברית": "usdm",
+	"קנדה":        "cdm",
+	"מקסיקו":      "mxndm",
+	"קוריאה":      "skdm",
+	"קוריאה ד":    "skdm",
+	"דרום קוריאה": "skdm",
+	"סין":         "chdm",
+	"אוסטרליה":    "audm",
+}
+
+// WheelSizeRegionForCountry infers the wheel-size market from a manufacturer
+// country, defaulting to the European market most Israeli imports follow
+func WheelSizeRegionForCountry(country string) string {
+	country = strings.TrimSpace(country)
+	if region, found := HebrewCountryToWheelSizeRegionMap[country]; found {
+		return region
+	}
+
+	// Country names are sometimes truncated or suffixed, as in "קוריאה דרום"
+	for hebrewName, region := range HebrewCountryToWheelSizeRegionMap {
+		if len([]rune(hebrewName)) >= 3 && strings.HasPrefix(country, hebrewName) {
+			return region
+		}
+	}
+
+	return config.WheelSizeDefaultRegion
+}
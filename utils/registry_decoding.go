@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+// EnumUnknown is the decoded value of registry values missing from the mapping tables
+const EnumUnknown = "unknown"
+
+var HebrewToEnglishFuelTypeMap = map[string]string{
+	"בנזין":        "petrol",
+	"דיזל":         "diesel",
+	"חשמל":         "electric",
+	"חשמל/בנזין":   "hybrid_petrol",
+	"חשמל/דיזל":    "hybrid_diesel",
+	"גפ\"מ":        "lpg",
+	"בנזין/גפ\"מ":  "petrol_lpg",
+	"גז טבעי דחוס": "cng",
+	"מימן":         "hydrogen",
+}
+
+var HebrewToEnglishOwnershipMap = map[string]string{
+	"פרטי":   "private",
+	"ליסינג": "leasing",
+	"השכרה":  "rental",
+	"חברה":   "company",
+	"ממשלתי": "government",
+	"סוחר":   "dealer",
+}
+
+// HebrewToEnglishColorMap is keyed by the base color, the first word of the
+// registry color such as "לבן" in "לבן שנהב"
+var HebrewToEnglishColorMap = map[string]string{
+	"לבן":   "white",
+	"שחור":  "black",
+	"כסף":   "silver",
+	"כסוף":  "silver",
+	"אפור":  "gray",
+	"כחול":  "blue",
+	"תכלת":  "light_blue",
+	"אדום":  "red",
+	"בורדו": "burgundy",
+	"ירוק":  "green",
+	"צהוב":  "yellow",
+	"כתום":  "orange",
+	"חום":   "brown",
+	"בז'":   "beige",
+	"זהב":   "gold",
+	"שנהב":  "ivory",
+	"סגול":  "purple",
+	"ורוד":  "pink",
+}
+
+var (
+	unknownRegistryValuesMu sync.Mutex
+	unknownRegistryValues   = map[string]struct{}{}
+)
+
+// decodeRegistryValue looks value up in table, logging each unmapped value once per field
+func decodeRegistryValue(field string, tableName string, table map[string]string, value string) string {
+	if englishValue, found := table[value]; found {
+		return englishValue
+	}
+
+	unknownRegistryValuesMu.Lock()
+	if _, seen := unknownRegistryValues[field+"\x00"+value]; !seen {
+		unknownRegistryValues[field+"\x00"+value] = struct{}{}
+		log.Printf("decodeRegistryValue: unmapped Hebrew %s: %q — consider adding to %s", field, value, tableName)
+	}
+	unknownRegistryValuesMu.Unlock()
+
+	return EnumUnknown
+}
+
+// ConvertOwnershipToEnglish maps the registry's Hebrew ownership (baalut) value to an English ownership type
+func ConvertOwnershipToEnglish(ownership string) string {
+	return decodeRegistryValue("ownership", "HebrewToEnglishOwnershipMap", HebrewToEnglishOwnershipMap, strings.TrimSpace(ownership))
+}
+
+// ConvertFuelTypeToEnglish maps the registry's Hebrew fuel type (sug_delek_nm) to an English fuel type
+func ConvertFuelTypeToEnglish(fuelType string) string {
+	return decodeRegistryValue("fuel type", "HebrewToEnglishFuelTypeMap", HebrewToEnglishFuelTypeMap, strings.TrimSpace(fuelType))
+}
+
+// ConvertColorToEnglish maps the registry's Hebrew color (tzeva_rechev) to its English base color
+func ConvertColorToEnglish(color string) string {
+	baseColor, _, _ := strings.Cut(strings.TrimSpace(color), " ")
+	return decodeRegistryValue("color", "HebrewToEnglishColorMap", HebrewToEnglishColorMap, baseColor)
+}
+
+// englishToRegistryValue maps an English value, such as "light blue" or
+// "light_blue", back to the Hebrew registry value table decodes to it. Hebrew
+// and unmapped values are returned as is.
+func englishToRegistryValue(table map[string]string, value string) string {
+	value = strings.TrimSpace(value)
+	if !isEnglish(value) {
+		return value
+	}
+
+	englishValue := strings.NewReplacer(" ", "_", "-", "_").Replace(value)
+	var hebrewValues []string
+	for hebrewValue, mappedValue := range table {
+		if strings.EqualFold(mappedValue, englishValue) {
+			hebrewValues = append(hebrewValues, hebrewValue)
+		}
+	}
+
+	if len(hebrewValues) == 0 {
+		return value
+	}
+
+	// Several spellings can map to one value; pick one deterministically
+	sort.Strings(hebrewValues)
+	return hebrewValues[0]
+}
+
+// ConvertFuelTypeToHebrew maps an English fuel type back to the registry's Hebrew sug_delek_nm
+func ConvertFuelTypeToHebrew(fuelType string) string {
+	return englishToRegistryValue(HebrewToEnglishFuelTypeMap, fuelType)
+}
+
+// ConvertColorToHebrew maps an English base color back to the registry's Hebrew tzeva_rechev base color
+func ConvertColorToHebrew(color string) string {
+	return englishToRegistryValue(HebrewToEnglishColorMap, color)
+}
+
+// PollutionLevelBand groups the registry pollution group (kvutzat_zihum),
+// 1 being the cleanest and 15 the most polluting, into five bands
+func PollutionLevelBand(pollutionLevel int) string {
+	switch {
+	case pollutionLevel >= 1 && pollutionLevel <= 3:
+		return "very_low"
+	case pollutionLevel >= 4 && pollutionLevel <= 6:
+		return "low"
+	case pollutionLevel >= 7 && pollutionLevel <= 9:
+		return "medium"
+	case pollutionLevel >= 10 && pollutionLevel <= 12:
+		return "high"
+	case pollutionLevel >= 13 && pollutionLevel <= 15:
+		return "very_high"
+	default:
+		return EnumUnknown
+	}
+}
+
+// SafetyFeaturesLevelBand groups the registry safety equipment level
+// (ramat_eivzur_betihuty), 0 having no assistance systems and 8 the most
+func SafetyFeaturesLevelBand(safetyFeaturesLevel int) string {
+	switch {
+	case safetyFeaturesLevel == 0:
+		return "none"
+	case safetyFeaturesLevel >= 1 && safetyFeaturesLevel <= 2:
+		return "low"
+	case safetyFeaturesLevel >= 3 && safetyFeaturesLevel <= 4:
+		return "medium"
+	case safetyFeaturesLevel >= 5 && safetyFeaturesLevel <= 6:
+		return "high"
+	case safetyFeaturesLevel >= 7 && safetyFeaturesLevel <= 8:
+		return "very_high"
+	default:
+		return EnumUnknown
+	}
+}
+
+// DecodeVehicleFields decodes the coded fields present on the vehicle details,
+// returning nil when none are present
+func DecodeVehicleFields(vehicleDetails vehicle.VehicleResponse) *vehicle.DecodedFields {
+	decoded := &vehicle.DecodedFields{}
+
+	if fuelType := strings.TrimSpace(vehicleDetails.FuelType); fuelType != "" {
+		decoded.FuelType = &vehicle.LabeledValue{Value: ConvertFuelTypeToEnglish(fuelType), Label: fuelType}
+	}
+	if ownership := strings.TrimSpace(vehicleDetails.Ownership); ownership != "" {
+		decoded.Ownership = &vehicle.LabeledValue{Value: ConvertOwnershipToEnglish(ownership), Label: ownership}
+	}
+	if color := strings.TrimSpace(vehicleDetails.Color); color != "" {
+		decoded.Color = &vehicle.LabeledValue{Value: ConvertColorToEnglish(color), Label: color}
+	}
+	if vehicleDetails.PollutionLevel > 0 {
+		decoded.PollutionLevel = &vehicle.LabeledValue{
+			Value: PollutionLevelBand(vehicleDetails.PollutionLevel),
+			Label: strconv.Itoa(vehicleDetails.PollutionLevel),
+		}
+	}
+	// Vehicles without a registry safety level leave SafetyFeaturesLevel unset
+	if safetyFeaturesLevel, ok := vehicleDetails.SafetyFeaturesLevel.(int); ok {
+		decoded.SafetyFeaturesLevel = &vehicle.LabeledValue{
+			Value: SafetyFeaturesLevelBand(safetyFeaturesLevel),
+			Label: strconv.Itoa(safetyFeaturesLevel),
+		}
+	}
+
+	if *decoded == (vehicle.DecodedFields{}) {
+		return nil
+	}
+
+	return decoded
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+// tireSizePattern matches ISO metric tire markings such as "205/55 R16 91V",
+// "215/45ZR17 91W", "185R14C 102/100Q" or "225/45 R17 94W XL": width, optional
+// aspect ratio, construction, rim diameter, the optional service description,
+// then an optional extra load or run-flat marker
+var tireSizePattern = regexp.MustCompile(`^(?:P|LT)?(\d{3})(?:\s*/\s*(\d{2,3}))?\s*(ZR|R|B|D|-)\s*(\d{2}(?:\.\d)?)\s*C?(?:\s*\(?(\d{2,3})(?:/\d{2,3})?\s*([A-Z])\)?)?(?:\s*(?:XL|RF|EL|SL|REINF|RFT|ROF|ZP|SSR))?$`)
+
+// TireLoadIndexKg maps a tire load index to the maximum load per tire in kilograms
+var TireLoadIndexKg = map[int]int{
+	60: 250, 61: 257, 62: 265, 63: 272, 64: 280, 65: 290, 66: 300, 67: 307, 68: 315, 69: 325,
+	70: 335, 71: 345, 72: 355, 73: 365, 74: 375, 75: 387, 76: 400, 77: 412, 78: 425, 79: 437,
+	80: 450, 81: 462, 82: 475, 83: 487, 84: 500, 85: 515, 86: 530, 87: 545, 88: 560, 89: 580,
+	90: 600, 91: 615, 92: 630, 93: 650, 94: 670, 95: 690, 96: 710, 97: 730, 98: 750, 99: 775,
+	100: 800, 101: 825, 102: 850, 103: 875, 104: 900, 105: 925, 106: 950, 107: 975, 108: 1000, 109: 1030,
+	110: 1060, 111: 1090, 112: 1120, 113: 1150, 114: 1180, 115: 1215, 116: 1250, 117: 1285, 118: 1320, 119: 1360,
+	120: 1400, 121: 1450, 122: 1500, 123: 1550, 124: 1600, 125: 1650, 126: 1700, 127: 1750, 128: 1800, 129: 1850,
+	130: 1900, 131: 1950, 132: 2000, 133: 2060, 134: 2120, 135: 2180, 136: 2240, 137: 2300, 138: 2360, 139: 2430,
+	140: 2500, 141: 2575, 142: 2650, 143: 2725, 144: 2800, 145: 2900, 146: 3000, 147: 3075, 148: 3150, 149: 3250,
+	150: 3350,
+}
+
+// TireSpeedRatingKmh maps a tire speed rating to its maximum speed in km/h
+var TireSpeedRatingKmh = map[string]int{
+	"J": 100, "K": 110, "L": 120, "M": 130, "N": 140, "P": 150, "Q": 160, "R": 170, "S": 180,
+	"T": 190, "U": 200, "H": 210, "V": 240, "W": 270, "Y": 300, "Z": 240,
+}
+
+// ParseTireSize parses a registry tire marking (zmig_kidmi / zmig_ahori)
+func ParseTireSize(tireSize string) (vehicle.TireSize, error) {
+	raw := strings.TrimSpace(tireSize)
+	match := tireSizePattern.FindStringSubmatch(strings.ToUpper(raw))
+	if match == nil {
+		return vehicle.TireSize{}, fmt.Errorf("unrecognized tire size %q", tireSize)
+	}
+
+	parsed := vehicle.TireSize{Raw: raw, Construction: match[3]}
+	if parsed.Construction == "-" {
+		parsed.Construction = "D"
+	}
+
+	parsed.Width, _ = strconv.Atoi(match[1])
+	if match[2] != "" {
+		parsed.AspectRatio, _ = strconv.Atoi(match[2])
+	}
+	parsed.RimDiameter, _ = strconv.ParseFloat(match[4], 64)
+
+	if match[5] != "" {
+		parsed.LoadIndex, _ = strconv.Atoi(match[5])
+		parsed.LoadKg = TireLoadIndexKg[parsed.LoadIndex]
+	}
+	if match[6] != "" {
+		parsed.SpeedRating = match[6]
+		parsed.MaxSpeedKmh = TireSpeedRatingKmh[parsed.SpeedRating]
+	}
+
+	return parsed, nil
+}
+
+// ParseTireSizeField parses a registry tire marking, returning nil when it is empty or unrecognized
+func ParseTireSizeField(tireSize string) *vehicle.TireSize {
+	if strings.TrimSpace(tireSize) == "" {
+		return nil
+	}
+
+	parsed, err := ParseTireSize(tireSize)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
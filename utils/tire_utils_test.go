@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"testing"
+
+	vehicle "car-license-number-fetcher/models"
+)
+
+func TestParseTireSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		tireSize string
+		want     vehicle.TireSize
+	}{
+		{
+			name:     "radial with service description",
+			tireSize: "205/55 R16 91V",
+			want:     vehicle.TireSize{Raw: "205/55 R16 91V", Width: 205, AspectRatio: 55, Construction: "R", RimDiameter: 16, LoadIndex: 91, LoadKg: 615, SpeedRating: "V", MaxSpeedKmh: 240},
+		},
+		{
+			name:     "without spaces",
+			tireSize: "205/55R16",
+			want:     vehicle.TireSize{Raw: "205/55R16", Width: 205, AspectRatio: 55, Construction: "R", RimDiameter: 16},
+		},
+		{
+			name:     "ZR construction",
+			tireSize: "215/45ZR17 91W",
+			want:     vehicle.TireSize{Raw: "215/45ZR17 91W", Width: 215, AspectRatio: 45, Construction: "ZR", RimDiameter: 17, LoadIndex: 91, LoadKg: 615, SpeedRating: "W", MaxSpeedKmh: 270},
+		},
+		{
+			name:     "extra load marker",
+			tireSize: "225/45 R17 94W XL",
+			want:     vehicle.TireSize{Raw: "225/45 R17 94W XL", Width: 225, AspectRatio: 45, Construction: "R", RimDiameter: 17, LoadIndex: 94, LoadKg: 670, SpeedRating: "W", MaxSpeedKmh: 270},
+		},
+		{
+			name:     "run-flat marker",
+			tireSize: "225/45R17 91W RFT",
+			want:     vehicle.TireSize{Raw: "225/45R17 91W RFT", Width: 225, AspectRatio: 45, Construction: "R", RimDiameter: 17, LoadIndex: 91, LoadKg: 615, SpeedRating: "W", MaxSpeedKmh: 270},
+		},
+		{
+			name:     "commercial dual load index",
+			tireSize: "185R14C 102/100Q",
+			want:     vehicle.TireSize{Raw: "185R14C 102/100Q", Width: 185, Construction: "R", RimDiameter: 14, LoadIndex: 102, LoadKg: 850, SpeedRating: "Q", MaxSpeedKmh: 160},
+		},
+		{
+			name:     "parenthesized service description",
+			tireSize: "255/35 ZR19 (96Y)",
+			want:     vehicle.TireSize{Raw: "255/35 ZR19 (96Y)", Width: 255, AspectRatio: 35, Construction: "ZR", RimDiameter: 19, LoadIndex: 96, LoadKg: 710, SpeedRating: "Y", MaxSpeedKmh: 300},
+		},
+		{
+			name:     "light truck prefix",
+			tireSize: "LT265/75R16",
+			want:     vehicle.TireSize{Raw: "LT265/75R16", Width: 265, AspectRatio: 75, Construction: "R", RimDiameter: 16},
+		},
+		{
+			name:     "diagonal dash construction",
+			tireSize: "155-13",
+			want:     vehicle.TireSize{Raw: "155-13", Width: 155, Construction: "D", RimDiameter: 13},
+		},
+		{
+			name:     "fractional rim diameter",
+			tireSize: "215/75 R17.5",
+			want:     vehicle.TireSize{Raw: "215/75 R17.5", Width: 215, AspectRatio: 75, Construction: "R", RimDiameter: 17.5},
+		},
+		{
+			name:     "lowercase with surrounding spaces",
+			tireSize: " 195/65r15 91h ",
+			want:     vehicle.TireSize{Raw: "195/65r15 91h", Width: 195, AspectRatio: 65, Construction: "R", RimDiameter: 15, LoadIndex: 91, LoadKg: 615, SpeedRating: "H", MaxSpeedKmh: 210},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTireSize(tt.tireSize)
+			if err != nil {
+				t.Fatalf("ParseTireSize(%q) returned error: %v", tt.tireSize, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTireSize(%q) = %+v, want %+v", tt.tireSize, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTireSizeInvalid(t *testing.T) {
+	for _, tireSize := range []string{"", "R16", "205/55", "205/55 R16 91V XX", "not a tire"} {
+		t.Run(tireSize, func(t *testing.T) {
+			if got, err := ParseTireSize(tireSize); err == nil {
+				t.Errorf("ParseTireSize(%q) = %+v, want an error", tireSize, got)
+			}
+		})
+	}
+}
+
+func TestParseTireSizeField(t *testing.T) {
+	if got := ParseTireSizeField(" "); got != nil {
+		t.Errorf("ParseTireSizeField of a blank marking = %+v, want nil", got)
+	}
+	if got := ParseTireSizeField("unknown"); got != nil {
+		t.Errorf("ParseTireSizeField of an unrecognized marking = %+v, want nil", got)
+	}
+	if got := ParseTireSizeField("205/55 R16"); got == nil || got.Width != 205 {
+		t.Errorf("ParseTireSizeField(%q) = %+v, want width 205", "205/55 R16", got)
+	}
+}
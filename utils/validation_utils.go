@@ -20,3 +20,39 @@ func GetPort() string {
 	}
 	return port
 }
+
+// GetVehicleDataAPIEndpoint retrieves the CKAN datastore_search endpoint from environment variable or returns default
+func GetVehicleDataAPIEndpoint() string {
+	endpoint := os.Getenv(config.VehicleDataAPIEndpointEnvVar)
+	if endpoint == "" {
+		endpoint = config.CKANDatastoreSearchEndpoint
+	}
+	return endpoint
+}
+
+// GetTirePressureProviders retrieves the order tire pressure providers are tried in
+// from a comma separated environment variable or returns the default order
+func GetTirePressureProviders() []string {
+	providers := os.Getenv(config.TirePressureProvidersEnvVar)
+	if providers == "" {
+		providers = config.DefaultTirePressureProviders
+	}
+
+	var names []string
+	for _, name := range strings.Split(providers, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// IsIncluded reports whether option appears in a comma separated include query value
+func IsIncluded(include string, option string) bool {
+	for _, value := range strings.Split(include, ",") {
+		if strings.EqualFold(strings.TrimSpace(value), option) {
+			return true
+		}
+	}
+	return false
+}